@@ -0,0 +1,94 @@
+// Command eosintf decodes Arista EOS interface IDs into interface names.
+//
+// IDs are given in hex (with a 0x prefix) or decimal as arguments, or one per
+// line on stdin when there are no arguments:
+//
+//	$ eosintf 0x000c0202
+//	Ethernet3/1/2
+//
+// With -encode the input is interface names and the hex IDs are printed:
+//
+//	$ eosintf -encode Ethernet3/1/2
+//	0x000c0202
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nemith/eosintf"
+)
+
+func main() {
+	encode := flag.Bool("encode", false, "encode interface names into IDs")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [-encode] [id|name ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if !run(flag.Args(), *encode, os.Stdin, os.Stdout, os.Stderr) {
+		os.Exit(1)
+	}
+}
+
+// run converts each argument, or each line of stdin if there are no
+// arguments.  It returns false if any input could not be converted.
+func run(args []string, encode bool, stdin io.Reader, stdout, stderr io.Writer) bool {
+	convert := decode
+	if encode {
+		convert = encodeName
+	}
+
+	ok := true
+	process := func(s string) {
+		out, err := convert(s)
+		if err != nil {
+			fmt.Fprintln(stderr, "eosintf:", err)
+			ok = false
+			return
+		}
+		fmt.Fprintln(stdout, out)
+	}
+
+	if len(args) > 0 {
+		for _, arg := range args {
+			process(arg)
+		}
+		return ok
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		process(line)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(stderr, "eosintf:", err)
+		return false
+	}
+	return ok
+}
+
+func decode(s string) (string, error) {
+	i, err := eosintf.ParseID(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+func encodeName(s string) (string, error) {
+	i, err := eosintf.ParseIntf(s)
+	if err != nil {
+		return "", err
+	}
+	return i.Format(eosintf.StyleRaw), nil
+}
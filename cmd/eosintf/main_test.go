@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tt := []struct {
+		name   string
+		args   []string
+		encode bool
+		stdin  string
+		want   string
+		ok     bool
+	}{
+		{"args", []string{"0x000c0202", "786946"}, false, "", "Ethernet3/1/2\nEthernet3/1/2\n", true},
+		{"stdin", nil, false, "0x000c0202\n\n0x02000064\n", "Ethernet3/1/2\nVlan100\n", true},
+		{"encode", []string{"Ethernet3/1/2"}, true, "", "0x000c0202\n", true},
+		{"encode stdin", nil, true, "Vlan100\n", "0x02000064\n", true},
+		{"bad id", []string{"bogus", "0x000c0202"}, false, "", "Ethernet3/1/2\n", false},
+		{"bad name", []string{"Bogus1"}, true, "", "", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			ok := run(tc.args, tc.encode, strings.NewReader(tc.stdin), &stdout, &stderr)
+
+			if ok != tc.ok {
+				t.Errorf("unexpected result (want %v, got %v, stderr %q)", tc.ok, ok, stderr.String())
+			}
+
+			if got := stdout.String(); got != tc.want {
+				t.Errorf("unexpected output (want %q, got %q)", tc.want, got)
+			}
+		})
+	}
+}
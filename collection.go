@@ -0,0 +1,115 @@
+package eosintf
+
+import "sort"
+
+// Diff returns the interfaces in b but not in a (added) and those in a but
+// not in b (removed).  Interfaces are compared with Equal and each result is
+// free of duplicates and sorted in natural order.
+func Diff(a, b []Intf) (added, removed []Intf) {
+	return difference(b, a), difference(a, b)
+}
+
+// GroupBySlot buckets interfaces by their slot (see Intf.Slot).  Interfaces
+// without a slot, such as Vlan or Port-Channel, are keyed by -1.  Each bucket
+// is sorted in natural order; duplicates are kept.
+func GroupBySlot(xs []Intf) map[int][]Intf {
+	groups := make(map[int][]Intf)
+	for _, i := range xs {
+		slot, ok := i.Slot()
+		if !ok {
+			slot = -1
+		}
+		groups[slot] = append(groups[slot], i)
+	}
+
+	for _, g := range groups {
+		sort.Sort(IntfSlice(g))
+	}
+	return groups
+}
+
+// IntfSet is a set of interfaces.  Members are stored normalized (see Key)
+// so IDs that differ only in bits unused by their type are the same member.
+// The zero value is a nil map; use NewIntfSet or make before calling Add.
+type IntfSet map[Intf]struct{}
+
+// NewIntfSet returns a set containing xs.
+func NewIntfSet(xs ...Intf) IntfSet {
+	s := make(IntfSet, len(xs))
+	s.Add(xs...)
+	return s
+}
+
+// Add adds the interfaces to the set.
+func (s IntfSet) Add(xs ...Intf) {
+	for _, i := range xs {
+		s[i.normalize()] = struct{}{}
+	}
+}
+
+// Contains reports whether i is in the set.
+func (s IntfSet) Contains(i Intf) bool {
+	_, ok := s[i.normalize()]
+	return ok
+}
+
+// Remove removes the interfaces from the set.
+func (s IntfSet) Remove(xs ...Intf) {
+	for _, i := range xs {
+		delete(s, i.normalize())
+	}
+}
+
+// Union returns a new set with the members of both s and other.
+func (s IntfSet) Union(other IntfSet) IntfSet {
+	out := make(IntfSet, len(s)+len(other))
+	for i := range s {
+		out[i] = struct{}{}
+	}
+	for i := range other {
+		out[i] = struct{}{}
+	}
+	return out
+}
+
+// Intersect returns a new set with the members in both s and other.
+func (s IntfSet) Intersect(other IntfSet) IntfSet {
+	out := make(IntfSet)
+	for i := range s {
+		if _, ok := other[i]; ok {
+			out[i] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Slice returns the members of the set sorted in natural order.
+func (s IntfSet) Slice() []Intf {
+	out := make([]Intf, 0, len(s))
+	for i := range s {
+		out = append(out, i)
+	}
+	sort.Sort(IntfSlice(out))
+	return out
+}
+
+// difference returns the interfaces in a that are not in b.
+func difference(a, b []Intf) []Intf {
+	seen := make(map[Intf]bool, len(a)+len(b))
+	for _, i := range b {
+		seen[i.normalize()] = true
+	}
+
+	var out []Intf
+	for _, i := range a {
+		key := i.normalize()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, i)
+	}
+
+	sort.Sort(IntfSlice(out))
+	return out
+}
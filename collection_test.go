@@ -0,0 +1,96 @@
+package eosintf
+
+import "testing"
+
+func parseIntfs(t *testing.T, names ...string) []Intf {
+	t.Helper()
+	xs := make([]Intf, len(names))
+	for i, name := range names {
+		intf, err := ParseIntf(name)
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		xs[i] = intf
+	}
+	return xs
+}
+
+func assertIntfs(t *testing.T, what string, got []Intf, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("unexpected %s (want %v, got %v)", what, want, got)
+		return
+	}
+	for i := range got {
+		if got[i].String() != want[i] {
+			t.Errorf("unexpected %s (want %v, got %v)", what, want, got)
+			return
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := parseIntfs(t, "Ethernet10", "Ethernet2", "Ethernet1", "Vlan100")
+	b := parseIntfs(t, "Ethernet2", "Ethernet3", "Vlan100", "Vlan20", "Ethernet3")
+
+	added, removed := Diff(a, b)
+	assertIntfs(t, "added", added, "Ethernet3", "Vlan20")
+	assertIntfs(t, "removed", removed, "Ethernet1", "Ethernet10")
+}
+
+func TestDiffNormalized(t *testing.T) {
+	a := []Intf{0x02000064} // Vlan100
+	b := []Intf{0x02001064} // Vlan100 with an unused bit set
+
+	added, removed := Diff(a, b)
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("unexpected difference (added %v, removed %v)", added, removed)
+	}
+}
+
+func TestGroupBySlot(t *testing.T) {
+	xs := parseIntfs(t,
+		"Ethernet3/10/1", "Vlan100", "Ethernet3/2/1", "Ethernet1",
+		"Management1/1", "Port-Channel10", "Ethernet4/1/1",
+	)
+
+	groups := GroupBySlot(xs)
+	if len(groups) != 5 {
+		t.Errorf("unexpected number of groups (want 5, got %d)", len(groups))
+	}
+
+	assertIntfs(t, "slot 0", groups[0], "Ethernet1")
+	assertIntfs(t, "slot 1", groups[1], "Management1/1")
+	assertIntfs(t, "slot 3", groups[3], "Ethernet3/2/1", "Ethernet3/10/1")
+	assertIntfs(t, "slot 4", groups[4], "Ethernet4/1/1")
+	assertIntfs(t, "no slot", groups[-1], "Vlan100", "Port-Channel10")
+}
+
+func TestIntfSet(t *testing.T) {
+	s := NewIntfSet(parseIntfs(t, "Ethernet10", "Ethernet2", "Vlan100")...)
+	s.Add(0x02001064) // Vlan100 with an unused bit set
+
+	assertIntfs(t, "members", s.Slice(), "Ethernet2", "Ethernet10", "Vlan100")
+
+	if !s.Contains(0x02000064) || !s.Contains(0x02001064) {
+		t.Error("expected Vlan100 to be a member")
+	}
+	if s.Contains(MustParseIntf("Ethernet1")) {
+		t.Error("unexpected member Ethernet1")
+	}
+
+	s.Remove(0x02001064)
+	assertIntfs(t, "members after remove", s.Slice(), "Ethernet2", "Ethernet10")
+}
+
+func TestIntfSetOps(t *testing.T) {
+	a := NewIntfSet(parseIntfs(t, "Ethernet1", "Ethernet2", "Vlan100")...)
+	b := NewIntfSet(parseIntfs(t, "Ethernet2", "Vlan100", "Vlan200")...)
+
+	assertIntfs(t, "union", a.Union(b).Slice(), "Ethernet1", "Ethernet2", "Vlan100", "Vlan200")
+	assertIntfs(t, "intersection", a.Intersect(b).Slice(), "Ethernet2", "Vlan100")
+
+	// The operands are not modified.
+	assertIntfs(t, "a", a.Slice(), "Ethernet1", "Ethernet2", "Vlan100")
+	assertIntfs(t, "b", b.Slice(), "Ethernet2", "Vlan100", "Vlan200")
+}
@@ -0,0 +1,27 @@
+package eosintf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// configKeyword introduces an interface stanza in EOS configuration.
+const configKeyword = "interface"
+
+// ConfigStanza returns the line that opens the interface's configuration
+// section in "show running-config", e.g. "interface Ethernet3/1/2".
+func (i Intf) ConfigStanza() string {
+	return configKeyword + " " + i.String()
+}
+
+// ParseConfigStanza parses a line such as "interface Ethernet3/1/2" into the
+// interface it configures.  Indentation is ignored and, as on the CLI, the
+// name may be in any form accepted by ParseIntf.
+func ParseConfigStanza(line string) (Intf, error) {
+	s := strings.TrimSpace(line)
+	n := len(configKeyword)
+	if len(s) <= n || !strings.EqualFold(s[:n], configKeyword) || s[n] != ' ' && s[n] != '\t' {
+		return 0, fmt.Errorf("%q is not an interface stanza", line)
+	}
+	return ParseIntf(s[n:])
+}
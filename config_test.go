@@ -0,0 +1,64 @@
+package eosintf
+
+import "testing"
+
+func TestConfigStanza(t *testing.T) {
+	tt := []struct {
+		input int
+		want  string
+	}{
+		{0x000c0202, "interface Ethernet3/1/2"},
+		{0x0e00000a, "interface Port-Channel10"},
+		{0x02000064, "interface Vlan100"},
+		{0x06000000, "interface Loopback0"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			intf := Intf(tc.input)
+			if got := intf.ConfigStanza(); got != tc.want {
+				t.Errorf("unexpected stanza (want %q, got %q)", tc.want, got)
+			}
+
+			got, err := ParseConfigStanza(tc.want)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != intf {
+				t.Errorf("unexpected interface (want %#08x, got %#08x)", tc.input, int(got))
+			}
+		})
+	}
+}
+
+func TestParseConfigStanzaLoose(t *testing.T) {
+	tt := []struct {
+		input string
+		want  string
+	}{
+		{"   interface Ethernet1", "Ethernet1"},
+		{"interface Et1\n", "Ethernet1"},
+		{"Interface\tpo10", "Port-Channel10"},
+	}
+
+	for _, tc := range tt {
+		got, err := ParseConfigStanza(tc.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+
+		if got.String() != tc.want {
+			t.Errorf("%q: unexpected interface (want %q, got %q)", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestParseConfigStanzaError(t *testing.T) {
+	for _, input := range []string{"", "interface", "interface ", "interfaceEthernet1", "router bgp 65000", "interface Bogus1"} {
+		if got, err := ParseConfigStanza(input); err == nil {
+			t.Errorf("%q: expected error, got %v", input, got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package eosintf
+
+import "fmt"
+
+// NewEthernet returns the Ethernet interface for the given slot, module and
+// port.  Fixed configuration switches use a slot and module of zero.
+func NewEthernet(slot, module, port int) (Intf, error) {
+	return newIntf(TypeEthernet, slot, module, port)
+}
+
+// NewPeerEthernet returns the PeerEthernet interface for the given slot,
+// module and port.  It shares its layout with Ethernet.
+func NewPeerEthernet(slot, module, port int) (Intf, error) {
+	return newIntf(TypePeerEthernet, slot, module, port)
+}
+
+// NewTest returns the Test interface for the given slot and port.
+func NewTest(slot, port int) (Intf, error) {
+	return newIntf(TypeTest, slot, port)
+}
+
+// NewTunnel returns the Tunnel interface with the given number.
+func NewTunnel(n int) (Intf, error) {
+	return newIntf(TypeTunnel, n)
+}
+
+// NewVxlan returns the Vxlan interface with the given number.
+func NewVxlan(n int) (Intf, error) {
+	return newIntf(TypeVXLAN, n)
+}
+
+// NewGRE returns the Gre interface with the given number.
+func NewGRE(n int) (Intf, error) {
+	return newIntf(TypeGRE, n)
+}
+
+// NewMLAG returns the Mlag interface with the given number.
+func NewMLAG(n int) (Intf, error) {
+	return newIntf(TypeMLAG, n)
+}
+
+// NewPortChannel returns the Port-Channel interface with the given number,
+// which must be between 1 and 8191.
+func NewPortChannel(n int) (Intf, error) {
+	return newIntf(TypePortChan, n)
+}
+
+// NewPeerPortChannel returns the PeerPort-Channel interface with the given
+// number, which must be between 1 and 8191.
+func NewPeerPortChannel(n int) (Intf, error) {
+	return newIntf(TypePeerPortChan, n)
+}
+
+// EthernetPorts returns the Ethernet interfaces for ports 1 through count on
+// the given slot and module.
+func EthernetPorts(slot, module, count int) ([]Intf, error) {
+	if max := TypeEthernet.MaxPort(); count < 0 || count > max {
+		return nil, fmt.Errorf("invalid port count %d (max %d)", count, max)
+	}
+
+	// Check the slot and module up front so they are rejected even when
+	// there are no ports.
+	if _, err := NewEthernet(slot, module, 1); err != nil {
+		return nil, err
+	}
+
+	xs := make([]Intf, 0, count)
+	for port := 1; port <= count; port++ {
+		i, err := NewEthernet(slot, module, port)
+		if err != nil {
+			return nil, err
+		}
+		xs = append(xs, i)
+	}
+	return xs, nil
+}
+
+// The configurable VLANs.  0 and 4095 are reserved by 802.1Q.
+const (
+	minVlan = 1
+	maxVlan = 4094
+)
+
+// Vlans returns the Vlan interfaces from through to inclusive.  Both must be
+// configurable VLANs, between 1 and 4094, even though the ID can also hold
+// Vlan0 and Vlan4095.
+func Vlans(from, to int) ([]Intf, error) {
+	if from < minVlan || to > maxVlan || from > to {
+		return nil, fmt.Errorf("invalid vlan range %d-%d (must be within %d-%d)", from, to, minVlan, maxVlan)
+	}
+
+	xs := make([]Intf, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		i, err := newIntf(TypeVlan, n)
+		if err != nil {
+			return nil, err
+		}
+		xs = append(xs, i)
+	}
+	return xs, nil
+}
+
+// newIntf packs the type and numeric components into an Intf.
+func newIntf(t IntfType, nums ...int) (Intf, error) {
+	port, err := packPort(t, nums)
+	if err != nil {
+		return 0, err
+	}
+	return NewFromRaw(uint32(t)<<typeShift | uint32(port)), nil
+}
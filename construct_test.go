@@ -0,0 +1,250 @@
+package eosintf
+
+import "testing"
+
+func TestNewEthernet(t *testing.T) {
+	tt := []struct {
+		slot, module, port int
+		want               int
+	}{
+		{3, 1, 2, 0x000c0202},
+		{127, 511, 511, 0x01ffffff},
+		{0, 0, 1, 0x00000001},
+	}
+
+	for _, tc := range tt {
+		got, err := NewEthernet(tc.slot, tc.module, tc.port)
+		if err != nil {
+			t.Errorf("NewEthernet(%d, %d, %d): unexpected error: %v", tc.slot, tc.module, tc.port, err)
+			continue
+		}
+
+		if got != Intf(tc.want) {
+			t.Errorf("NewEthernet(%d, %d, %d): unexpected id (want %#08x, got %#08x)",
+				tc.slot, tc.module, tc.port, tc.want, int(got))
+		}
+	}
+}
+
+func TestNewEthernetError(t *testing.T) {
+	tt := []struct {
+		slot, module, port int
+	}{
+		{128, 0, 1},
+		{0, 512, 1},
+		{0, 0, 512},
+		{0, 0, -1},
+	}
+
+	for _, tc := range tt {
+		if _, err := NewEthernet(tc.slot, tc.module, tc.port); err == nil {
+			t.Errorf("NewEthernet(%d, %d, %d): expected error", tc.slot, tc.module, tc.port)
+		}
+	}
+}
+
+func TestNewPeerEthernet(t *testing.T) {
+	got, err := NewPeerEthernet(3, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "PeerEthernet3/1/2"; got.String() != want {
+		t.Errorf("unexpected interface name (want %q, got %q)", want, got.String())
+	}
+}
+
+func TestEthernetPorts(t *testing.T) {
+	got, err := EthernetPorts(3, 1, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"Ethernet3/1/1", "Ethernet3/1/2", "Ethernet3/1/3", "Ethernet3/1/4"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of ports (want %d, got %d)", len(want), len(got))
+	}
+
+	for i, intf := range got {
+		if intf.String() != want[i] {
+			t.Errorf("unexpected port at %d (want %q, got %q)", i, want[i], intf)
+		}
+	}
+}
+
+func TestEthernetPortsLimits(t *testing.T) {
+	if got, err := EthernetPorts(0, 0, 511); err != nil || len(got) != 511 {
+		t.Errorf("unexpected result for 511 ports (got %d ports, err %v)", len(got), err)
+	}
+
+	if got, err := EthernetPorts(0, 0, 0); err != nil || len(got) != 0 {
+		t.Errorf("unexpected result for 0 ports (got %d ports, err %v)", len(got), err)
+	}
+
+	for _, count := range []int{-1, 512, 1 << 30} {
+		if _, err := EthernetPorts(0, 0, count); err == nil {
+			t.Errorf("count %d: expected error", count)
+		}
+	}
+
+	tt := []struct {
+		slot, module int
+	}{
+		{128, 0},
+		{0, 512},
+		{-1, 0},
+	}
+
+	for _, tc := range tt {
+		if _, err := EthernetPorts(tc.slot, tc.module, 0); err == nil {
+			t.Errorf("EthernetPorts(%d, %d, 0): expected error", tc.slot, tc.module)
+		}
+	}
+}
+
+func TestNewSingleNumber(t *testing.T) {
+	tt := []struct {
+		fn   func(int) (Intf, error)
+		n    int
+		want string
+	}{
+		{NewTunnel, 100, "Tunnel100"},
+		{NewTunnel, 4095, "Tunnel4095"},
+		{NewVxlan, 1, "Vxlan1"},
+		{NewVxlan, 65535, "Vxlan65535"},
+		{NewGRE, 10, "Gre10"},
+		{NewMLAG, 4094, "Mlag4094"},
+		{NewPortChannel, 10, "Port-Channel10"},
+		{NewPortChannel, 8191, "Port-Channel8191"},
+		{NewPeerPortChannel, 10, "PeerPort-Channel10"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			got, err := tc.fn(tc.n)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.String() != tc.want {
+				t.Errorf("unexpected interface name (want %q, got %q)", tc.want, got)
+			}
+
+			parsed, err := ParseIntf(tc.want)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			if parsed != got {
+				t.Errorf("unexpected round-trip id (want %#08x, got %#08x)", int(got), int(parsed))
+			}
+		})
+	}
+}
+
+func TestNewSingleNumberError(t *testing.T) {
+	for _, fn := range []func(int) (Intf, error){NewVxlan, NewGRE, NewMLAG} {
+		for _, n := range []int{-1, 65536} {
+			if got, err := fn(n); err == nil {
+				t.Errorf("%d: expected error, got %s", n, got)
+			}
+		}
+	}
+}
+
+func TestNewPortChannelError(t *testing.T) {
+	for _, fn := range []func(int) (Intf, error){NewPortChannel, NewPeerPortChannel} {
+		for _, n := range []int{-1, 0, 8192} {
+			if got, err := fn(n); err == nil {
+				t.Errorf("%d: expected error, got %s", n, got)
+			}
+		}
+	}
+}
+
+func TestNewTest(t *testing.T) {
+	tt := []struct {
+		slot, port int
+		want       string
+	}{
+		{3, 4, "Test3/4"},
+		{0, 1, "Test1"},
+		{4095, 4095, "Test4095/4095"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			got, err := NewTest(tc.slot, tc.port)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.String() != tc.want {
+				t.Errorf("unexpected interface name (want %q, got %q)", tc.want, got)
+			}
+
+			if slot, _, port, _ := got.Components(); slot != tc.slot || port != tc.port {
+				t.Errorf("unexpected components (want %d/%d, got %d/%d)", tc.slot, tc.port, slot, port)
+			}
+		})
+	}
+
+	for _, tc := range []struct{ slot, port int }{{4096, 1}, {1, 4096}} {
+		if _, err := NewTest(tc.slot, tc.port); err == nil {
+			t.Errorf("NewTest(%d, %d): expected error", tc.slot, tc.port)
+		}
+	}
+}
+
+func TestVlans(t *testing.T) {
+	got, err := Vlans(10, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"Vlan10", "Vlan11", "Vlan12"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of vlans (want %d, got %d)", len(want), len(got))
+	}
+
+	for i, intf := range got {
+		if intf.String() != want[i] {
+			t.Errorf("unexpected vlan at %d (want %q, got %q)", i, want[i], intf)
+		}
+	}
+
+	if got, err := Vlans(1, 4094); err != nil || len(got) != 4094 {
+		t.Errorf("unexpected full vlan range (want 4094 vlans, got %d, err %v)", len(got), err)
+	}
+}
+
+func TestVlansError(t *testing.T) {
+	for _, tc := range []struct{ from, to int }{{12, 10}, {-1, 1}, {0, 2}, {4094, 4095}, {4090, 4096}, {1, 1 << 30}, {-(1 << 30), 1}} {
+		if _, err := Vlans(tc.from, tc.to); err == nil {
+			t.Errorf("Vlans(%d, %d): expected error", tc.from, tc.to)
+		}
+	}
+}
+
+func TestPseudowireRoundTrip(t *testing.T) {
+	for _, n := range []int{1, 100, 65535} {
+		want, err := newIntf(TypePseudowire, n)
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %v", n, err)
+		}
+
+		got, err := ParseIntf(want.String())
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error: %v", want, err)
+		}
+
+		if got != want {
+			t.Errorf("%s: unexpected round-trip id (want %#08x, got %#08x)", want, int(want), int(got))
+		}
+	}
+
+	// Bits above the assumed 16 bit field are not part of the number.
+	if got := Intf(0x76010001).String(); got != "Pseudowire1" {
+		t.Errorf("unexpected interface name (want %q, got %q)", "Pseudowire1", got)
+	}
+}
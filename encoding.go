@@ -0,0 +1,175 @@
+package eosintf
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hasParsableName reports whether ParseIntf(i.String()) gives back i.  It is
+// false for unknown and registered types, and for IDs with port bits set
+// outside of the type's layout, whose names would lose information.  These
+// are encoded as the raw ID instead.
+func (i Intf) hasParsableName() bool {
+	_, builtin := intfTypeNames[i.Type()]
+	return builtin && i.IsValid()
+}
+
+// checkRaw returns an error if i does not hold a 32-bit ID.
+func (i Intf) checkRaw() error {
+	if Intf(i.Raw()) != i {
+		return fmt.Errorf("interface id %d does not fit in 32 bits", int(i))
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler by rendering the interface
+// name.  IDs whose name does not parse back to the same ID are rendered in
+// hex instead (e.g. "0x1c000001"), which UnmarshalText also accepts.
+func (i Intf) MarshalText() ([]byte, error) {
+	if err := i.checkRaw(); err != nil {
+		return nil, err
+	}
+	if !i.hasParsableName() {
+		return []byte(i.Format(StyleRaw)), nil
+	}
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing an interface
+// name with ParseIntf, or a raw ID with a "0x" prefix with ParseID.
+func (i *Intf) UnmarshalText(text []byte) error {
+	s := string(text)
+	parse := ParseIntf
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		parse = ParseID
+	}
+
+	v, err := parse(s)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler by encoding the interface name as a
+// JSON string.  IDs whose name does not parse back to the same ID are
+// encoded as the raw 32-bit ID as a JSON number.
+func (i Intf) MarshalJSON() ([]byte, error) {
+	if err := i.checkRaw(); err != nil {
+		return nil, err
+	}
+	if !i.hasParsableName() {
+		return strconv.AppendUint(nil, uint64(i.Raw()), 10), nil
+	}
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  It accepts either an interface
+// name as a JSON string or, for compatibility with the old encoding, the raw
+// 32-bit ID as a JSON number.
+func (i *Intf) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return i.UnmarshalText([]byte(s))
+	}
+
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("interface must be a name or 32-bit id: %v", err)
+	}
+	if n < 0 || n > 0xffffffff {
+		return fmt.Errorf("interface id %d does not fit in 32 bits", n)
+	}
+	*i = NewFromRaw(uint32(n))
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler by encoding the 32-bit ID
+// as 4 bytes in big-endian order.  It fails if the Intf holds a value that is
+// not a 32-bit ID; on 32-bit platforms every value is one.
+func (i Intf) MarshalBinary() ([]byte, error) {
+	if err := i.checkRaw(); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(i))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.  data must be
+// exactly 4 bytes as produced by MarshalBinary.
+func (i *Intf) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return fmt.Errorf("interface id must be 4 bytes, got %d", len(data))
+	}
+	*i = Intf(binary.BigEndian.Uint32(data))
+	return nil
+}
+
+// FromBytes reads an interface ID from 4 bytes in big-endian order, as
+// produced by Bytes.
+func FromBytes(b []byte) (Intf, error) {
+	var i Intf
+	if err := i.UnmarshalBinary(b); err != nil {
+		return 0, err
+	}
+	return i, nil
+}
+
+// Bytes returns the 32-bit ID (see Raw) as 4 bytes in big-endian order.
+// Unlike MarshalBinary it never fails: bits above 32 are discarded.
+func (i Intf) Bytes() []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, i.Raw())
+	return b
+}
+
+// Value implements driver.Valuer by storing the interface name.  As with
+// MarshalJSON, IDs whose name does not parse back to the same ID are stored
+// as the raw 32-bit ID as an int64.
+func (i Intf) Value() (driver.Value, error) {
+	if err := i.checkRaw(); err != nil {
+		return nil, err
+	}
+	if !i.hasParsableName() {
+		return int64(i.Raw()), nil
+	}
+	return i.String(), nil
+}
+
+// Scan implements sql.Scanner.  It accepts an interface name as a string (or
+// []byte) or the raw 32-bit ID as an int64.
+func (i *Intf) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return i.UnmarshalText([]byte(v))
+	case []byte:
+		return i.UnmarshalText(v)
+	case int64:
+		if v < 0 || v > 0xffffffff {
+			return fmt.Errorf("interface id %d does not fit in 32 bits", v)
+		}
+		*i = NewFromRaw(uint32(v))
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into Intf", src)
+}
+
+// Set implements flag.Value so an Intf can be used directly as a command line
+// flag with flag.Var.
+func (i *Intf) Set(s string) error {
+	return i.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,332 @@
+package eosintf
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	tt := []string{"Ethernet1", "Ethernet3/1/2", "Vlan100", "Port-Channel10"}
+
+	for _, name := range tt {
+		t.Run(name, func(t *testing.T) {
+			want, err := ParseIntf(name)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			text, err := want.MarshalText()
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %v", err)
+			}
+
+			if string(text) != name {
+				t.Errorf("unexpected text (want %q, got %q)", name, text)
+			}
+
+			var got Intf
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatalf("unexpected unmarshal error: %v", err)
+			}
+
+			if got != want {
+				t.Errorf("unexpected interface (want %#08x, got %#08x)", int(want), int(got))
+			}
+		})
+	}
+}
+
+// TestEncodingRaw checks IDs whose names do not parse back to the same ID
+// are encoded as the raw ID and survive a round-trip.
+func TestEncodingRaw(t *testing.T) {
+	const typ IntfType = 0x73
+	RegisterType(typ, "Example", nil)
+	defer func() {
+		registryMu.Lock()
+		delete(registry, typ)
+		registryMu.Unlock()
+	}()
+
+	tt := []struct {
+		input uint32
+		text  string
+		json  string
+	}{
+		{0x1c000001, "0x1c000001", "469762049"},  // unknown type 0x0e
+		{0x02001064, "0x02001064", "33558628"},   // Vlan100 with bit 12 set
+		{0xe6000000, "0xe6000000", "3858759680"}, // registered type
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.text, func(t *testing.T) {
+			want := NewFromRaw(tc.input)
+
+			text, err := want.MarshalText()
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %v", err)
+			}
+			if string(text) != tc.text {
+				t.Errorf("unexpected text (want %q, got %q)", tc.text, text)
+			}
+
+			var got Intf
+			if err := got.UnmarshalText(text); err != nil || got != want {
+				t.Errorf("unexpected text round-trip (want %#08x, got %#08x, %v)", tc.input, got.Raw(), err)
+			}
+
+			js, err := json.Marshal(want)
+			if err != nil {
+				t.Fatalf("unexpected json error: %v", err)
+			}
+			if string(js) != tc.json {
+				t.Errorf("unexpected json (want %s, got %s)", tc.json, js)
+			}
+
+			got = 0
+			if err := json.Unmarshal(js, &got); err != nil || got != want {
+				t.Errorf("unexpected json round-trip (want %#08x, got %#08x, %v)", tc.input, got.Raw(), err)
+			}
+
+			v, err := want.Value()
+			if err != nil {
+				t.Fatalf("unexpected value error: %v", err)
+			}
+
+			got = 0
+			if err := got.Scan(v); err != nil || got != want {
+				t.Errorf("unexpected sql round-trip (want %#08x, got %#08x, %v)", tc.input, got.Raw(), err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	i := Intf(0x000c0202)
+	if err := i.UnmarshalText([]byte("Bogus1")); err == nil {
+		t.Error("expected error")
+	}
+
+	if i != Intf(0x000c0202) {
+		t.Errorf("interface modified on error (got %#08x)", int(i))
+	}
+}
+
+func TestUnmarshalTextEmpty(t *testing.T) {
+	for _, input := range []string{"", "  "} {
+		i := Intf(0x000c0202)
+		if err := i.UnmarshalText([]byte(input)); !errors.Is(err, ErrEmptyName) {
+			t.Errorf("%q: unexpected error (want ErrEmptyName, got %v)", input, err)
+		}
+
+		if i != Intf(0x000c0202) {
+			t.Errorf("%q: interface modified on error (got %#08x)", input, int(i))
+		}
+	}
+
+	var i Intf
+	if err := json.Unmarshal([]byte(`""`), &i); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("unexpected json error (want ErrEmptyName, got %v)", err)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	got, err := json.Marshal(Intf(0x000c0202))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := `"Ethernet3/1/2"`; string(got) != want {
+		t.Errorf("unexpected json (want %s, got %s)", want, got)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tt := []struct {
+		input string
+		want  int
+	}{
+		{`"Ethernet3/1/2"`, 0x000c0202},
+		{`"Vlan100"`, 0x02000064},
+		{`786946`, 0x000c0202},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			var got Intf
+			if err := json.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != Intf(tc.want) {
+				t.Errorf("unexpected interface (want %#08x, got %#08x)", tc.want, int(got))
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	tt := []string{`"Bogus1"`, `""`, `-1`, `4294967296`, `1.5`, `true`}
+
+	for _, input := range tt {
+		t.Run(input, func(t *testing.T) {
+			var got Intf
+			if err := json.Unmarshal([]byte(input), &got); err == nil {
+				t.Errorf("expected error, got %#08x", int(got))
+			}
+		})
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  []byte
+	}{
+		{0x000c0202, []byte{0x00, 0x0c, 0x02, 0x02}},
+		{0xcc000001, []byte{0xcc, 0x00, 0x00, 0x01}},
+	}
+
+	for _, tc := range tt {
+		got, err := Intf(tc.input).MarshalBinary()
+		if err != nil {
+			t.Fatalf("%#08x: unexpected marshal error: %v", tc.input, err)
+		}
+
+		if !bytes.Equal(got, tc.want) {
+			t.Errorf("%#08x: unexpected bytes (want %x, got %x)", tc.input, tc.want, got)
+		}
+
+		var intf Intf
+		if err := intf.UnmarshalBinary(got); err != nil {
+			t.Fatalf("%#08x: unexpected unmarshal error: %v", tc.input, err)
+		}
+
+		if intf != Intf(tc.input) {
+			t.Errorf("unexpected interface (want %#08x, got %#08x)", tc.input, int(intf))
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	want := []byte{0x00, 0x0c, 0x02, 0x02}
+	if got := Intf(0x000c0202).Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("unexpected bytes (want %x, got %x)", want, got)
+	}
+
+	got, err := FromBytes(want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "Ethernet3/1/2"; got.String() != want {
+		t.Errorf("unexpected interface name (want %q, got %q)", want, got)
+	}
+
+	for _, input := range [][]byte{nil, {0x01}, {0x00, 0x0c, 0x02, 0x02, 0x00}} {
+		if got, err := FromBytes(input); err == nil {
+			t.Errorf("%x: expected error, got %#08x", input, int(got))
+		}
+	}
+}
+
+func TestBinaryError(t *testing.T) {
+	// On 32-bit platforms -1 is the ID 0xffffffff.
+	if strconv.IntSize == 64 {
+		if _, err := Intf(-1).MarshalBinary(); err == nil {
+			t.Error("expected marshal error for negative id")
+		}
+		if _, err := Intf(-1).MarshalText(); err == nil {
+			t.Error("expected text marshal error for negative id")
+		}
+		if _, err := json.Marshal(Intf(-1)); err == nil {
+			t.Error("expected json marshal error for negative id")
+		}
+	}
+
+	for _, input := range [][]byte{nil, {0x01}, {0x00, 0x0c, 0x02, 0x02, 0x00}} {
+		var intf Intf
+		if err := intf.UnmarshalBinary(input); err == nil {
+			t.Errorf("%x: expected unmarshal error", input)
+		}
+	}
+}
+
+func TestSQLValue(t *testing.T) {
+	got, err := Intf(0x000c0202).Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "Ethernet3/1/2"; got != driver.Value(want) {
+		t.Errorf("unexpected value (want %q, got %v)", want, got)
+	}
+}
+
+func TestSQLScan(t *testing.T) {
+	tt := []struct {
+		input driver.Value
+		want  int
+	}{
+		{"Ethernet3/1/2", 0x000c0202},
+		{[]byte("Vlan100"), 0x02000064},
+		{int64(0x000c0202), 0x000c0202},
+	}
+
+	for _, tc := range tt {
+		var got Intf
+		if err := got.Scan(tc.input); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.input, err)
+			continue
+		}
+
+		if got != Intf(tc.want) {
+			t.Errorf("%v: unexpected interface (want %#08x, got %#08x)", tc.input, tc.want, int(got))
+		}
+	}
+}
+
+func TestSQLScanError(t *testing.T) {
+	for _, input := range []driver.Value{nil, "Bogus1", int64(-1), 1.5} {
+		var got Intf
+		if err := got.Scan(input); err == nil {
+			t.Errorf("%v: expected error", input)
+		}
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var intf Intf
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&intf, "intf", "interface name")
+
+	if err := fs.Parse([]string{"-intf", "Ethernet1/1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := Intf(0x00000201); intf != want {
+		t.Errorf("unexpected interface (want %#08x, got %#08x)", int(want), int(intf))
+	}
+}
+
+func TestFlagError(t *testing.T) {
+	var intf Intf
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&intf, "intf", "interface name")
+
+	err := fs.Parse([]string{"-intf", "Bogus1"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(err.Error(), `"Bogus1"`) {
+		t.Errorf("error does not name the offending token: %v", err)
+	}
+}
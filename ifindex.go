@@ -0,0 +1,54 @@
+package eosintf
+
+import "sync"
+
+var (
+	ifIndexMu  sync.RWMutex
+	ifIndexMap = map[Intf]int{}
+)
+
+// RegisterIfIndex records the SNMP ifIndex for an interface, overriding any
+// derived value returned by IfIndex.  It is safe for concurrent use.
+func RegisterIfIndex(i Intf, ifIndex int) {
+	ifIndexMu.Lock()
+	defer ifIndexMu.Unlock()
+	ifIndexMap[i] = ifIndex
+}
+
+// IfIndex returns the SNMP ifIndex of the interface.  Values registered with
+// RegisterIfIndex are returned first.  Otherwise the conventional EOS ifIndex
+// is derived for the types where it is known:
+//
+//	Ethernet<n>      n
+//	Management<n>    999000 + n
+//	Port-Channel<n>  1000000 + n
+//	Loopback<n>      5000000 + n
+//
+// ok is false for all other interfaces, including Ethernet and Management
+// interfaces with a slot or module, as the ifIndex is assigned by the switch
+// and must be registered.
+func (i Intf) IfIndex() (int, bool) {
+	ifIndexMu.RLock()
+	ifIndex, ok := ifIndexMap[i]
+	ifIndexMu.RUnlock()
+	if ok {
+		return ifIndex, true
+	}
+
+	nums := unpackPort(i.Type(), i.RawPort())
+	switch i.Type() {
+	case TypeEthernet:
+		if nums[0] == 0 && nums[1] == 0 {
+			return nums[2], true
+		}
+	case TypeMgmt:
+		if nums[0] == 0 {
+			return 999000 + nums[1], true
+		}
+	case TypePortChan:
+		return 1000000 + nums[0], true
+	case TypeLoopback:
+		return 5000000 + nums[0], true
+	}
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package eosintf
+
+import "testing"
+
+func TestIfIndex(t *testing.T) {
+	tt := []struct {
+		input int
+		want  int
+		ok    bool
+	}{
+		{0x00000001, 1, true},       // Ethernet1
+		{0x00000030, 48, true},      // Ethernet48
+		{0x04000001, 999001, true},  // Management1
+		{0x0e00000a, 1000010, true}, // Port-Channel10
+		{0x06000000, 5000000, true}, // Loopback0
+		{0x000c0202, 0, false},      // Ethernet3/1/2
+		{0x04000201, 0, false},      // Management1/1
+		{0x0c000000, 0, false},      // Cpu
+	}
+
+	for _, tc := range tt {
+		got, ok := Intf(tc.input).IfIndex()
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%#08x: unexpected ifIndex (want %d, %v, got %d, %v)", tc.input, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestRegisterIfIndex(t *testing.T) {
+	intf := Intf(0x000c0202) // Ethernet3/1/2
+	RegisterIfIndex(intf, 3001002)
+	defer func() {
+		ifIndexMu.Lock()
+		delete(ifIndexMap, intf)
+		ifIndexMu.Unlock()
+	}()
+
+	if got, ok := intf.IfIndex(); got != 3001002 || !ok {
+		t.Errorf("unexpected ifIndex (want %d, true, got %d, %v)", 3001002, got, ok)
+	}
+}
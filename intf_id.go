@@ -20,52 +20,60 @@ package eosintf
 //     'Ethernet3/1/2'
 //
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type IntfType int
 
 const (
-	TypeEthernet               IntfType = 0x0  // Etherney
-	TypeVlan                            = 0x1  // Vlan
-	TypeMgmt                            = 0x2  // Mangement
-	TypeLoopback                        = 0x03 // Loopback
-	TypeNull                            = 0x04 // Null
-	TypeInternal                        = 0x05 // Internal
-	TypeCPU                             = 0x06 // Cpu
-	TypePortChan                        = 0x07 // Port-Channel
-	TypePeerEthernet                    = 0x08 // PeerEthernet
-	TypePeerPortChan                    = 0x09 // PeerPort-Channel
-	TypeTest                            = 0x0a // Test
-	TypeSwitch                          = 0x0b // Switch
-	TypeL2QuerierLink                   = 0x0c // l2QuerierLink
-	TypeMlag                            = 0x0d // mlag
-	TypeTunnel                          = 0x0f // Tunnel
-	TypeMLAG                            = 0x10 // Mlag
-	TypeDefaultTestPort                 = 0x15 // DefaultTestPort
-	TypeDefaultEthMgmtPort              = 0x16 // DefaultEthManagementPort
-	TypeDefaultEthSwitchedPort          = 0x17 // DefaultEthSwitchedPort
-	TypeDefaultEthInternalPort          = 0x18 // DefaultEthInternalPort
-	TypeHost                            = 0x19 // host
-	TypeDefaultEthDataLinkPort          = 0x22 // DefaultEthDataLinkPort
-	TypeVXLAN                           = 0x38 // Vxlan
-	TypeGRE                             = 0x39 // Gre
-	TypeDynamicTunnel                   = 0x3a // DynamicTunnel.0
-	TypePsuedowire                      = 0x3b // Pseudowire
-	TypeTunnelTap                       = 0x3c // tunnelTap
-	TypeFabric                          = 0x48 // Fabric1
-	TypeRegister                        = 0x4f // Register
-	TypeOpenFlowRouter                  = 0x5a // OpenFlowRouter
-	TypeT2Recirc                        = 0x63 // T2Recirc1
-	TypeFwd                             = 0x66 // fwd0
+	TypeEthernet               IntfType = 0x0  // Ethernet
+	TypeVlan                   IntfType = 0x1  // Vlan
+	TypeMgmt                   IntfType = 0x2  // Management
+	TypeLoopback               IntfType = 0x03 // Loopback
+	TypeNull                   IntfType = 0x04 // Null
+	TypeInternal               IntfType = 0x05 // Internal
+	TypeCPU                    IntfType = 0x06 // Cpu
+	TypePortChan               IntfType = 0x07 // Port-Channel
+	TypePeerEthernet           IntfType = 0x08 // PeerEthernet
+	TypePeerPortChan           IntfType = 0x09 // PeerPort-Channel
+	TypeTest                   IntfType = 0x0a // Test
+	TypeSwitch                 IntfType = 0x0b // Switch
+	TypeL2QuerierLink          IntfType = 0x0c // l2QuerierLink
+	TypeMlag                   IntfType = 0x0d // mlag
+	TypeTunnel                 IntfType = 0x0f // Tunnel
+	TypeMLAG                   IntfType = 0x10 // Mlag
+	TypeDefaultTestPort        IntfType = 0x15 // DefaultTestPort
+	TypeDefaultEthMgmtPort     IntfType = 0x16 // DefaultEthManagementPort
+	TypeDefaultEthSwitchedPort IntfType = 0x17 // DefaultEthSwitchedPort
+	TypeDefaultEthInternalPort IntfType = 0x18 // DefaultEthInternalPort
+	TypeHost                   IntfType = 0x19 // host
+	TypeDefaultEthDataLinkPort IntfType = 0x22 // DefaultEthDataLinkPort
+	TypeVXLAN                  IntfType = 0x38 // Vxlan
+	TypeGRE                    IntfType = 0x39 // Gre
+	TypeDynamicTunnel          IntfType = 0x3a // DynamicTunnel.0
+	TypePseudowire             IntfType = 0x3b // Pseudowire
+	TypeTunnelTap              IntfType = 0x3c // tunnelTap
+	TypeFabric                 IntfType = 0x48 // Fabric1
+	TypeRegister               IntfType = 0x4f // Register
+	TypeOpenFlowRouter         IntfType = 0x5a // OpenFlowRouter
+	TypeT2Recirc               IntfType = 0x63 // T2Recirc1
+	TypeFwd                    IntfType = 0x66 // fwd0
 )
 
+// TypePsuedowire is the original, misspelled, name of TypePseudowire.
+//
+// Deprecated: Use TypePseudowire.
+const TypePsuedowire = TypePseudowire
+
 var intfTypeNames = map[IntfType]string{
 	TypeEthernet:               "Ethernet",
 	TypeVlan:                   "Vlan",
-	TypeMgmt:                   "Mangement",
+	TypeMgmt:                   "Management",
 	TypeLoopback:               "Loopback",
 	TypeNull:                   "Null",
 	TypeInternal:               "Internal",
@@ -88,7 +96,7 @@ var intfTypeNames = map[IntfType]string{
 	TypeVXLAN:                  "Vxlan",
 	TypeGRE:                    "Gre",
 	TypeDynamicTunnel:          "DynamicTunnel",
-	TypePsuedowire:             "Pseudowire",
+	TypePseudowire:             "Pseudowire",
 	TypeTunnelTap:              "tunnelTap",
 	TypeFabric:                 "Fabric",
 	TypeRegister:               "Register",
@@ -97,84 +105,552 @@ var intfTypeNames = map[IntfType]string{
 	TypeFwd:                    "fwd",
 }
 
+// intfTypeShortNames holds the abbreviated names EOS accepts and renders for
+// the common types (e.g. "Et3/1/2").
+var intfTypeShortNames = map[IntfType]string{
+	TypeEthernet: "Et",
+	TypePortChan: "Po",
+	TypeVlan:     "Vl",
+	TypeMgmt:     "Ma",
+	TypeLoopback: "Lo",
+	TypeTunnel:   "Tu",
+	TypeVXLAN:    "Vx",
+}
+
+// intfTypeTitleNames holds title cased display names for the types EOS names
+// in lowercase (see TitleString).
+var intfTypeTitleNames = map[IntfType]string{
+	TypeL2QuerierLink: "L2QuerierLink",
+	TypeMlag:          "Mlag",
+	TypeHost:          "Host",
+	TypeTunnelTap:     "TunnelTap",
+	TypeFwd:           "Fwd",
+}
+
+var (
+	intfTypesByName       map[string]IntfType
+	intfTypesByFoldedName map[string]IntfType
+	intfTypesByShortName  map[string]IntfType // keyed by lowercase name
+)
+
+func init() {
+	intfTypesByName = make(map[string]IntfType, len(intfTypeNames))
+	intfTypesByFoldedName = make(map[string]IntfType, len(intfTypeNames))
+	ambiguous := make(map[string]bool)
+
+	for t, name := range intfTypeNames {
+		intfTypesByName[name] = t
+
+		// Some names (mlag and Mlag) differ only by case.  These can only be
+		// looked up by their exact name.
+		folded := strings.ToLower(name)
+		if _, ok := intfTypesByFoldedName[folded]; ok {
+			ambiguous[folded] = true
+		}
+		intfTypesByFoldedName[folded] = t
+	}
+
+	for name := range ambiguous {
+		delete(intfTypesByFoldedName, name)
+	}
+
+	intfTypesByShortName = make(map[string]IntfType, len(intfTypeShortNames))
+	for t, name := range intfTypeShortNames {
+		intfTypesByShortName[strings.ToLower(name)] = t
+	}
+}
+
+// TypeFromName returns the IntfType for the given type name (e.g.
+// "Ethernet").  Names are matched case-insensitively unless the name is
+// ambiguous without case, in which case the exact name is required.
+func TypeFromName(name string) (IntfType, bool) {
+	if t, ok := intfTypesByName[name]; ok {
+		return t, true
+	}
+	t, ok := intfTypesByFoldedName[strings.ToLower(name)]
+	return t, ok
+}
+
+// KnownTypes returns the built-in interface types sorted by type value.
+// Types added with RegisterType are not included.
+func KnownTypes() []IntfType {
+	ts := make([]IntfType, 0, len(intfTypeNames))
+	for t := range intfTypeNames {
+		ts = append(ts, t)
+	}
+	sort.Slice(ts, func(i, j int) bool { return ts[i] < ts[j] })
+	return ts
+}
+
+// Name returns the type's name, e.g. "Ethernet".  It is the same as String.
+func (t IntfType) Name() string {
+	return t.String()
+}
+
 func (t IntfType) String() string {
 	s, ok := intfTypeNames[t]
 	if !ok {
+		if r, ok := lookupRegistered(t); ok {
+			return r.name
+		}
 		return "UNKNOWN"
 	}
 	return s
 }
 
+// HasPort reports whether interfaces of this type are rendered with a port
+// number.  It is false for the singleton types such as Cpu and Switch.
+func (t IntfType) HasPort() bool {
+	return len(layoutFor(t).fields) > 0
+}
+
+// IsPhysical reports whether the type is a physical port: Ethernet,
+// PeerEthernet, Management, Internal, Fabric and T2Recirc.
+func (t IntfType) IsPhysical() bool {
+	switch t {
+	case TypeEthernet, TypePeerEthernet, TypeMgmt, TypeInternal, TypeFabric, TypeT2Recirc:
+		return true
+	}
+	return false
+}
+
+// IsLogical reports whether the type is a logical interface: Vlan, Loopback,
+// Null, Port-Channel, PeerPort-Channel, Mlag, Tunnel, Vxlan, Gre,
+// DynamicTunnel, Pseudowire, tunnelTap and Register.
+func (t IntfType) IsLogical() bool {
+	switch t {
+	case TypeVlan, TypeLoopback, TypeNull, TypePortChan, TypePeerPortChan, TypeMLAG,
+		TypeTunnel, TypeVXLAN, TypeGRE, TypeDynamicTunnel, TypePseudowire, TypeTunnelTap, TypeRegister:
+		return true
+	}
+	return false
+}
+
+// IsTunnel reports whether the type is a tunnel or overlay: Tunnel, Vxlan,
+// Gre, DynamicTunnel, tunnelTap and Pseudowire.
+func (t IntfType) IsTunnel() bool {
+	switch t {
+	case TypeTunnel, TypeVXLAN, TypeGRE, TypeDynamicTunnel, TypeTunnelTap, TypePseudowire:
+		return true
+	}
+	return false
+}
+
+// IsManagement reports whether the type is an out-of-band Management port.
+// DefaultEthManagementPort is not included: it is the default profile for
+// management ports rather than a port itself.
+func (t IntfType) IsManagement() bool {
+	return t == TypeMgmt
+}
+
+// IsPeer reports whether the type is the MLAG peer's view of a local type:
+// PeerEthernet or PeerPort-Channel.  A peer type uses the same layout and
+// numbering as its local counterpart and only the type bits differ, so
+// PeerEthernet3/1/2 is the peer switch's Ethernet3/1/2.
+func (t IntfType) IsPeer() bool {
+	return t == TypePeerEthernet || t == TypePeerPortChan
+}
+
+// peerTypes maps each local type to its MLAG peer type and back.
+var peerTypes = map[IntfType]IntfType{
+	TypeEthernet:     TypePeerEthernet,
+	TypePeerEthernet: TypeEthernet,
+	TypePortChan:     TypePeerPortChan,
+	TypePeerPortChan: TypePortChan,
+}
+
+// IsInternal reports whether the type is only used internally by EOS and is
+// never configured by users: Cpu, Test, Switch, l2QuerierLink, mlag, host,
+// fwd, OpenFlowRouter and the Default* port profiles.  Every known type is
+// exactly one of physical, logical or internal.
+//
+// There are two MLAG types.  TypeMLAG (0x10, "Mlag") is the user facing MLAG
+// interface and uses 16 bits for its number like the other overlay types.
+// TypeMlag (0x0d, "mlag") uses only 9 bits and, like the other lowercase
+// names, appears to be an internal interface used for the peer link.  Neither
+// has been confirmed against EOS documentation.
+func (t IntfType) IsInternal() bool {
+	switch t {
+	case TypeCPU, TypeTest, TypeSwitch, TypeL2QuerierLink, TypeMlag, TypeHost, TypeFwd,
+		TypeOpenFlowRouter, TypeDefaultTestPort, TypeDefaultEthMgmtPort,
+		TypeDefaultEthSwitchedPort, TypeDefaultEthInternalPort, TypeDefaultEthDataLinkPort:
+		return true
+	}
+	return false
+}
+
+// Intf is an EOS interface ID.
+//
+// The zero value is not a valid interface.  It is an Ethernet interface with
+// no slot, module or port, and no such port exists as Ethernet ports are
+// numbered from 1.  It renders as the bare type name "Ethernet", IsValid
+// reports false and ParseIntf rejects "Ethernet" (and "" with ErrEmptyName),
+// so no name parses to the zero value.  Use IsZero to detect it.
+//
+// The same holds for every type that has a number but no number 0, such as
+// Port-Channel.  Types that do have a number 0 render it (Vlan0, Management0,
+// Null0), except for the DefaultEth* port profiles which render it as the
+// bare profile name.
 type Intf int
 
+// IsZero reports whether i is the zero value.
+func (i Intf) IsZero() bool {
+	return i == 0
+}
+
+// NewFromRaw returns the Intf for a raw 32-bit interface ID.
+func NewFromRaw(v uint32) Intf {
+	return Intf(v)
+}
+
+// FromInt returns the Intf for v, checking that it is a 32-bit interface ID.
+// Use a plain Intf(v) conversion when v is known to be in range.
+func FromInt(v int) (Intf, error) {
+	if v < 0 || int64(v) > 0xffffffff {
+		return 0, fmt.Errorf("interface id %d out of range", v)
+	}
+	return Intf(v), nil
+}
+
+// Raw returns the 32-bit interface ID.  Any bits above 32 are discarded.
+func (i Intf) Raw() uint32 {
+	return uint32(i)
+}
+
 func (i Intf) Type() IntfType {
 	// top 7 bits
-	return IntfType(int(i) >> 25)
+	// Unsigned so a negative Intf is not sign-extended into the type.
+	return IntfType(i.Raw() >> typeShift)
+}
+
+// TypeOnly returns the interface with all port bits cleared, leaving only the
+// type.  It renders as the bare type name, or with an explicit zero for types
+// such as Vlan0 and Management0.  For types numbered from 1 the result is a
+// key for the type rather than a valid interface (see IsValid).
+func (i Intf) TypeOnly() Intf {
+	return NewFromRaw(uint32(i.Type()) << typeShift)
+}
+
+// IsManagement reports whether the interface is an out-of-band Management
+// port (see IntfType.IsManagement).
+func (i Intf) IsManagement() bool {
+	return i.Type().IsManagement()
+}
+
+// Is reports whether the interface is of type t.  It is shorthand for
+// i.Type() == t.
+func (i Intf) Is(t IntfType) bool {
+	return i.Type() == t
+}
+
+// IsAny reports whether the interface is of any of the types ts.
+func (i Intf) IsAny(ts ...IntfType) bool {
+	typ := i.Type()
+	for _, t := range ts {
+		if typ == t {
+			return true
+		}
+	}
+	return false
 }
 
 func (i Intf) RawPort() int {
 	// bottom 25 bits
-	return int(i) & 0x1ffffff
+	return int(i.Raw() & rawPortMask)
 }
 
+// Port returns the rendered port number of the interface (e.g. "3/1/2").  The
+// layout of the port bits for each type is defined in the layouts table.
 func (i Intf) Port() string {
-	n := i.RawPort()
+	t := i.Type()
 
-	switch i.Type() {
-	case TypeEthernet, TypePeerEthernet:
-		slot := n & 0x1fc0000 >> 18 // bits 18 - 24
-		mod := n & 0x3fe00 >> 9     // bits 9 - 17
-		port := n & 0x1ff           // bits 0 - 9
-		return fmtNums(slot, mod, port)
-	case TypeFabric, TypeT2Recirc:
-		// TODO: figure this out
-		return ""
-	case TypeMgmt, TypeInternal:
-		slot := n & 0x3fe00 >> 9 // bits 9 - 17
-		port := n & 0x1ff        // bits 0 - 9
-		return fmtNums(slot, port)
-	case TypeTest:
-		slot := n & 0xfff000 >> 12 // bits 12-24
-		port := n & 0xfff
-		return fmtNums(slot, port)
-	case TypeFwd:
-		// bits 0
-		return strconv.Itoa(n & 0x1)
-	case TypeDefaultEthSwitchedPort:
-		// bits 0 - 8
-		return fmtNums(n & 0xff)
-	case TypeMlag:
-		// bits 0 - 9
-		return fmtNums(n & 0x1ff)
-	case TypeVlan, TypeLoopback, TypeNull, TypeTunnel, TypeHost, TypeRegister:
-		// bits 0 - 12
-		return fmtNums(n & 0xfff)
-	case TypePortChan, TypePeerPortChan:
-		// bits 0 - 13
-		return fmtNums(n & 0x1fff)
-	case TypeMLAG, TypeVXLAN, TypeGRE:
-		// bits 0 - 16
-		return fmtNums(n & 0xffff)
-	case TypeDynamicTunnel:
-		return fmt.Sprintf("%d.0", n)
-	case TypeCPU, TypeSwitch, TypeL2QuerierLink, TypeDefaultTestPort, TypeDefaultEthMgmtPort,
-		TypeDefaultEthInternalPort, TypeDefaultEthDataLinkPort, TypeOpenFlowRouter:
-		return ""
-	}
-	return fmtNums(n)
-}
-
-func fmtNums(nums ...int) string {
-	parts := make([]string, 0, len(nums))
-	for _, n := range nums {
-		if n == 0 {
-			continue
+	// Fast path for the common single number types (Vlan, Port-Channel, ...)
+	// which avoids the intermediate buffer.
+	if l, ok := layouts[t]; ok && len(l.fields) == 1 && l.suffix == "" {
+		f := l.fields[0]
+		n := i.RawPort() >> f.offset & f.max()
+		if n == 0 && !l.showZero {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+
+	var buf [32]byte
+	return string(appendPort(buf[:0], t, i.RawPort()))
+}
+
+var (
+	// ErrUnknownType is returned by PortString for types that are neither
+	// built in nor registered.
+	ErrUnknownType = errors.New("unknown interface type")
+
+	// ErrUndecodedPort is returned by PortString for known types whose port
+	// layout has not been worked out yet.
+	ErrUndecodedPort = errors.New("interface port layout unknown")
+)
+
+// PortString is like Port but also reports whether the port was decoded.
+// Types without a port return an empty string and a nil error.  Types whose
+// layout is not known return the raw port number (as Port does) along with
+// ErrUnknownType or ErrUndecodedPort.
+func (i Intf) PortString() (string, error) {
+	t := i.Type()
+	if _, ok := intfTypeNames[t]; !ok {
+		if _, ok := lookupRegistered(t); !ok {
+			return i.Port(), ErrUnknownType
 		}
-		parts = append(parts, strconv.Itoa(n))
+		return i.Port(), nil
+	}
+
+	if layoutFor(t).undecoded {
+		return i.Port(), ErrUndecodedPort
+	}
+	return i.Port(), nil
+}
+
+// Components returns the numeric slot, module and port of interfaces that
+// have that structure.  Ethernet and PeerEthernet have all three, Management,
+// Internal and Test have a slot and port but no module.  ok is false for all
+// other types.
+func (i Intf) Components() (slot, module, port int, ok bool) {
+	t := i.Type()
+	nums := unpackPort(t, i.RawPort())
+
+	// The numbers follow the layout: slot/module/port or slot/port.
+	switch len(nums) {
+	case 3:
+		return nums[0], nums[1], nums[2], true
+	case 2:
+		return nums[0], 0, nums[1], true
+	}
+	return 0, 0, 0, false
+}
+
+// Slot returns the slot (linecard) number for the types that have one:
+// Ethernet, PeerEthernet, Management, Internal and Test.  Interfaces on fixed
+// switches have a slot of 0.  ok is false for all other types.
+func (i Intf) Slot() (slot int, ok bool) {
+	slot, _, _, ok = i.Components()
+	return slot, ok
+}
+
+// SameSlot reports whether i and other both have a slot (see Slot) and it is
+// the same.  All interfaces of a fixed switch have slot 0 and so share a slot.
+func (i Intf) SameSlot(other Intf) bool {
+	a, ok := i.Slot()
+	if !ok {
+		return false
+	}
+	b, ok := other.Slot()
+	return ok && a == b
+}
+
+// IsBreakout reports whether the interface looks like a lane of a breakout
+// port on a fixed switch, e.g. Ethernet1/1, where the module bits hold the
+// front panel port and the port bits hold the lane.  This is a heuristic: the
+// ID has no breakout flag, so a fixed switch port whose transceiver is not
+// broken out but is still named Ethernet1/1 cannot be told apart, and modular
+// switches (which have a slot) are never reported as breakouts.
+func (i Intf) IsBreakout() bool {
+	slot, module, _, ok := i.Components()
+	if !ok || i.Type() != TypeEthernet && i.Type() != TypePeerEthernet {
+		return false
+	}
+	return slot == 0 && module != 0
+}
+
+// IsLAG reports whether the interface is a link aggregation: Port-Channel,
+// PeerPort-Channel or either of the Mlag types.
+func (i Intf) IsLAG() bool {
+	switch i.Type() {
+	case TypePortChan, TypePeerPortChan, TypeMlag, TypeMLAG:
+		return true
+	}
+	return false
+}
+
+// Peer toggles between an interface and its MLAG peer counterpart, e.g.
+// Ethernet3/1/2 and PeerEthernet3/1/2 or Port-Channel10 and
+// PeerPort-Channel10.  The port bits are kept as is.  ok is false for types
+// without a peer counterpart.
+func (i Intf) Peer() (peer Intf, ok bool) {
+	t, ok := peerTypes[i.Type()]
+	if !ok {
+		return 0, false
 	}
-	return strings.Join(parts, "/")
+	return NewFromRaw(uint32(t)<<typeShift | uint32(i.RawPort())), true
 }
 
-func (i *Intf) String() string {
-	return fmt.Sprintf("%s%s", i.Type(), i.Port())
+// IsValid reports whether the interface has a known type and no port bits set
+// outside of the fields used by that type.  For example Ethernet uses all 25
+// port bits (slot, module and port), Vlan only the low 12 bits and types
+// without a number, such as Cpu, none at all.  Types numbered from 1 must
+// also have a non-zero number, so the zero value (see Intf) is not valid.
+func (i Intf) IsValid() bool {
+	t := i.Type()
+	if _, ok := intfTypeNames[t]; !ok {
+		// The layout of registered types is unknown so any port is valid.
+		_, ok := lookupRegistered(t)
+		return ok
+	}
+
+	if i.RawPort() == 0 && layoutFor(t).fromOne() {
+		return false
+	}
+	return i.RawPort()&^portMask(t) == 0
+}
+
+// Equal reports whether i and other decode to the same interface.  Port bits
+// that are not used by the type are ignored, so for example two Cpu IDs are
+// always equal and two Vlans are compared only on the low 12 bits.  The bits
+// used by each type are those of its layout (see Port()); unknown types
+// compare all 25 port bits.
+func (i Intf) Equal(other Intf) bool {
+	return i.normalize() == other.normalize()
+}
+
+// Key returns the raw ID with the port bits not used by the type cleared.
+// Interfaces that are Equal, and so render identically, have the same key,
+// which makes it suitable for keying maps and caches.
+func (i Intf) Key() uint32 {
+	return i.normalize().Raw()
+}
+
+// normalize clears the port bits not used by the type.
+func (i Intf) normalize() Intf {
+	t := i.Type()
+	return NewFromRaw(uint32(t)<<typeShift | uint32(i.RawPort()&portMask(t)))
+}
+
+func (i Intf) String() string {
+	var buf [32]byte
+	return string(i.AppendFormat(buf[:0]))
+}
+
+var nameCache sync.Map // map[Intf]string
+
+// CachedString is like String but remembers the result so repeated calls for
+// the same interface do not render the name again.  Entries are never
+// evicted so this should only be used when the set of interfaces is bounded.
+func (i Intf) CachedString() string {
+	if s, ok := nameCache.Load(i); ok {
+		return s.(string)
+	}
+	s := i.String()
+	nameCache.Store(i, s)
+	return s
+}
+
+// AppendFormat appends the interface name, as returned by String, to b and
+// returns the extended buffer.
+func (i Intf) AppendFormat(b []byte) []byte {
+	b = append(b, i.Type().String()...)
+	return appendPort(b, i.Type(), i.RawPort())
+}
+
+// FormatSep renders the full name with sep between the numbers instead of
+// '/', e.g. FormatSep("_") gives "Ethernet3_1_2".  This is useful for
+// filenames and URLs.  Types added with RegisterType render their port as
+// returned by their decoder.
+func (i Intf) FormatSep(sep string) string {
+	var buf [32]byte
+	b := append(buf[:0], i.Type().String()...)
+	return string(appendPortSep(b, i.Type(), i.RawPort(), sep))
+}
+
+// DecodeNames returns the names of the given interface IDs.  It is
+// equivalent to calling String() on each ID but renders all of the names
+// into a single buffer, so the returned strings share one allocation.
+func DecodeNames(ids []int) []string {
+	buf := make([]byte, 0, 16*len(ids))
+	ends := make([]int, len(ids))
+	for k, id := range ids {
+		buf = Intf(id).AppendFormat(buf)
+		ends[k] = len(buf)
+	}
+
+	s := string(buf)
+	names := make([]string, len(ids))
+	start := 0
+	for k, end := range ends {
+		names[k] = s[start:end]
+		start = end
+	}
+	return names
+}
+
+// GoString implements fmt.GoStringer so that %#v shows the decoded name along
+// with the raw ID, e.g. eosintf.Intf(0x000c0202 /* Ethernet3/1/2 */).
+func (i Intf) GoString() string {
+	hex := strconv.FormatUint(uint64(i.Raw()), 16)
+	if len(hex) < 8 {
+		hex = strings.Repeat("0", 8-len(hex)) + hex
+	}
+	return "eosintf.Intf(0x" + hex + " /* " + i.String() + " */)"
+}
+
+// Debug returns the raw breakdown of the interface ID for reverse
+// engineering, e.g. "type=0x00(Ethernet) raw=0x000c0202 slot=3 module=1
+// port=2" where raw is the port bits.
+func (i Intf) Debug() string {
+	t := i.Type()
+	s := fmt.Sprintf("type=%#02x(%s) raw=%#08x", int(t), t, i.RawPort())
+
+	var names []string
+	nums := unpackPort(t, i.RawPort())
+	switch len(nums) {
+	case 1:
+		names = []string{"port"}
+	case 2:
+		names = []string{"slot", "port"}
+	case 3:
+		names = []string{"slot", "module", "port"}
+	}
+	for k, n := range nums {
+		s += fmt.Sprintf(" %s=%d", names[k], n)
+	}
+	return s
+}
+
+// ShortString returns the abbreviated interface name as used by EOS (e.g.
+// "Et3/1/2" or "Po10").  Types without an abbreviation use their full name.
+func (i Intf) ShortString() string {
+	name, ok := intfTypeShortNames[i.Type()]
+	if !ok {
+		name = i.Type().String()
+	}
+	return name + i.Port()
+}
+
+// TitleString returns the name with the lowercase internal type names title
+// cased for display, e.g. "Host1" rather than "host1".  All other names are
+// the same as String.  The result is for display only: both mlag1 and Mlag1
+// render as "Mlag1", so use String for anything that is parsed back.
+func (i Intf) TitleString() string {
+	name, ok := intfTypeTitleNames[i.Type()]
+	if !ok {
+		return i.String()
+	}
+	return name + i.Port()
+}
+
+// NameStyle selects how Format renders an interface.
+type NameStyle int
+
+const (
+	StyleFull  NameStyle = iota // Ethernet3/1/2
+	StyleShort                  // Et3/1/2
+	StyleRaw                    // 0x000c0202
+)
+
+// Format renders the interface in the given style.  Unknown styles render
+// the full name.
+func (i Intf) Format(style NameStyle) string {
+	switch style {
+	case StyleShort:
+		return i.ShortString()
+	case StyleRaw:
+		return fmt.Sprintf("0x%08x", i.Raw())
+	}
+	return i.String()
 }
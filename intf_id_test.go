@@ -1,15 +1,56 @@
 package eosintf
 
-import "testing"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestDocExample ties the example in the package comment (0x000c0202 is
+// Ethernet3/1/2) to the decode, encode and parse paths.
+func TestDocExample(t *testing.T) {
+	const id = 0x000c0202
+
+	if got := Intf(id).String(); got != "Ethernet3/1/2" {
+		t.Errorf("unexpected decoded name (want %q, got %q)", "Ethernet3/1/2", got)
+	}
+
+	got, err := NewEthernet(3, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Raw() != id {
+		t.Errorf("unexpected encoded id (want %#08x, got %#08x)", id, got.Raw())
+	}
+
+	if parsed := MustParseIntf("Ethernet3/1/2"); parsed.Raw() != id {
+		t.Errorf("unexpected parsed id (want %#08x, got %#08x)", id, parsed.Raw())
+	}
+}
 
 func TestIntf(t *testing.T) {
 	tt := []struct {
-		input int
+		input uint32
 		want  string
 	}{
 		{0x000c0202, "Ethernet3/1/2"},
 		{0x01ffffff, "Ethernet127/511/511"},
 		{0x00000001, "Ethernet1"},
+		{0x000c0002, "Ethernet3/0/2"},
+		{0x00000202, "Ethernet1/2"},
+		{0x000c0200, "Ethernet3/1/0"},
+		{0x04000000, "Management0"},
+		{0x04000001, "Management1"},
+		{0x04000201, "Management1/1"},
+		{0x04000402, "Management2/2"},
+		{0x90000001, "Fabric1"},
+		{0x90000010, "Fabric16"},
+		{0xc6000001, "T2Recirc1"},
+		{0xc6000002, "T2Recirc2"},
+		{0x74000000, "DynamicTunnel0.0"},
+		{0x74000005, "DynamicTunnel5.0"},
+		{0x7400ffff, "DynamicTunnel65535.0"},
 	}
 
 	for _, tc := range tt {
@@ -23,3 +64,1003 @@ func TestIntf(t *testing.T) {
 		})
 	}
 }
+
+func TestIntfFormatValue(t *testing.T) {
+	intf := Intf(0x000c0202)
+	want := "Ethernet3/1/2"
+
+	for _, verb := range []string{"%s", "%v"} {
+		if got := fmt.Sprintf(verb, intf); got != want {
+			t.Errorf("unexpected %s output (want %q, got %q)", verb, want, got)
+		}
+	}
+}
+
+func TestTypeFromName(t *testing.T) {
+	tt := []struct {
+		input string
+		want  IntfType
+		ok    bool
+	}{
+		{"Ethernet", TypeEthernet, true},
+		{"ethernet", TypeEthernet, true},
+		{"Port-Channel", TypePortChan, true},
+		{"port-channel", TypePortChan, true},
+		{"Vlan", TypeVlan, true},
+		{"mlag", TypeMlag, true},
+		{"Mlag", TypeMLAG, true},
+		{"MLAG", 0, false},
+		{"Bogus", 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := TypeFromName(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("unexpected ok (want %v, got %v)", tc.ok, ok)
+			}
+
+			if got != tc.want {
+				t.Errorf("unexpected type (want %v, got %v)", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTypeFromNameAll(t *testing.T) {
+	for typ, name := range intfTypeNames {
+		got, ok := TypeFromName(name)
+		if !ok || got != typ {
+			t.Errorf("%q did not resolve to %#x (got %#x, ok %v)", name, int(typ), int(got), ok)
+		}
+	}
+}
+
+func TestIntfIsValid(t *testing.T) {
+	tt := []struct {
+		input int
+		want  bool
+	}{
+		{0x000c0202, true},  // Ethernet3/1/2
+		{0x01ffffff, true},  // Ethernet127/511/511
+		{0x02000064, true},  // Vlan100
+		{0x02001000, false}, // Vlan with bit 12 set
+		{0x0c000000, true},  // Cpu
+		{0x0c000001, false}, // Cpu with a port number
+		{0x1c000000, false}, // type 0x0e is unknown
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.input).IsValid(); got != tc.want {
+			t.Errorf("%#08x: unexpected validity (want %v, got %v)", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestIntfComponents(t *testing.T) {
+	tt := []struct {
+		input              int
+		slot, module, port int
+		ok                 bool
+	}{
+		{0x00000001, 0, 0, 1, true},  // Ethernet1
+		{0x000c0202, 3, 1, 2, true},  // Ethernet3/1/2
+		{0x100c0202, 3, 1, 2, true},  // PeerEthernet3/1/2
+		{0x04000201, 1, 0, 1, true},  // Management1/1
+		{0x14003004, 3, 0, 4, true},  // Test3/4
+		{0x02000064, 0, 0, 0, false}, // Vlan100
+		{0x0c000000, 0, 0, 0, false}, // Cpu
+	}
+
+	for _, tc := range tt {
+		slot, module, port, ok := Intf(tc.input).Components()
+		if slot != tc.slot || module != tc.module || port != tc.port || ok != tc.ok {
+			t.Errorf("%#08x: unexpected components (want %d, %d, %d, %v, got %d, %d, %d, %v)",
+				tc.input, tc.slot, tc.module, tc.port, tc.ok, slot, module, port, ok)
+		}
+	}
+}
+
+func TestIntfShortString(t *testing.T) {
+	tt := []struct {
+		input int
+		want  string
+	}{
+		{0x000c0202, "Et3/1/2"},
+		{0x0e00000a, "Po10"},
+		{0x02000064, "Vl100"},
+		{0x06000000, "Lo0"},
+		{0x06000001, "Lo1"},
+		{0x1e000005, "Tu5"},
+		{0x70000001, "Vx1"},
+		{0x100c0202, "PeerEthernet3/1/2"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := Intf(tc.input).ShortString(); got != tc.want {
+				t.Errorf("unexpected short name (want %q, got %q)", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIntfTitleString(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+	}{
+		{0x32000001, "Host1"},
+		{0x1a000001, "Mlag1"},
+		{0xcc000001, "Fwd1"},
+		{0x18000000, "L2QuerierLink"},
+		{0x000c0202, "Ethernet3/1/2"},
+		{0x20000001, "Mlag1"},
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.input).TitleString(); got != tc.want {
+			t.Errorf("%#08x: unexpected title name (want %q, got %q)", tc.input, tc.want, got)
+		}
+	}
+
+	// Every title name must only differ from the EOS name by case.
+	for typ, title := range intfTypeTitleNames {
+		if !strings.EqualFold(title, intfTypeNames[typ]) {
+			t.Errorf("%s: title name %q does not match", typ, title)
+		}
+	}
+}
+
+// TestIntfNoPort documents that the singleton types render without a number.
+// If any of these turn out to carry an index (e.g. Cpu1 on a multi-ASIC box)
+// these tests need updating.
+func TestIntfNoPort(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+	}{
+		{0x0c000000, "Cpu"},
+		{0x16000000, "Switch"},
+		{0x18000000, "l2QuerierLink"},
+		{0x2a000000, "DefaultTestPort"},
+		{0xb4000000, "OpenFlowRouter"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := Intf(tc.input).String(); got != tc.want {
+				t.Errorf("unexpected interface name (want %q, got %q)", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIntfFormat(t *testing.T) {
+	tt := []struct {
+		style NameStyle
+		want  string
+	}{
+		{StyleFull, "Ethernet3/1/2"},
+		{StyleShort, "Et3/1/2"},
+		{StyleRaw, "0x000c0202"},
+	}
+
+	intf := Intf(0x000c0202)
+	for _, tc := range tt {
+		if got := intf.Format(tc.style); got != tc.want {
+			t.Errorf("unexpected name for style %d (want %q, got %q)", tc.style, tc.want, got)
+		}
+	}
+}
+
+func TestIntfEqual(t *testing.T) {
+	tt := []struct {
+		a, b uint32
+		want bool
+	}{
+		{0x000c0202, 0x000c0202, true},  // Ethernet3/1/2
+		{0x000c0202, 0x000c0201, false}, // Ethernet3/1/2, Ethernet3/1/1
+		{0x02000064, 0x02001064, true},  // Vlan100 with bit 12 set
+		{0x0c000000, 0x0c000001, true},  // Cpu with stray port bits
+		{0xcc000001, 0xcc000003, true},  // fwd1 with bit 1 set
+		{0x00000001, 0x02000001, false}, // Ethernet1, Vlan1
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.a).Equal(Intf(tc.b)); got != tc.want {
+			t.Errorf("%#08x == %#08x: want %v, got %v", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
+
+func TestIntfKey(t *testing.T) {
+	a, b := Intf(0x02000064), Intf(0x02001064) // Vlan100, with bit 12 set
+	if a.Key() != b.Key() {
+		t.Errorf("unexpected keys (%#08x != %#08x)", a.Key(), b.Key())
+	}
+	if a.String() != b.String() {
+		t.Errorf("unexpected names (%q != %q)", a, b)
+	}
+	if a.Key() != 0x02000064 {
+		t.Errorf("unexpected key (want %#08x, got %#08x)", 0x02000064, a.Key())
+	}
+
+	if c := Intf(0x02000065); c.Key() == a.Key() {
+		t.Errorf("%s and %s share key %#08x", a, c, a.Key())
+	}
+}
+
+func FuzzIntfString(f *testing.F) {
+	for _, seed := range []uint32{
+		0x000c0202, 0x01ffffff, 0x00000001, 0x000c0002, 0x00000202,
+		0x02000064, 0x0c000000, 0x90000001, 0xc6000001, 0x74000005, 0xcc000001,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, id uint32) {
+		intf := Intf(id)
+		s := intf.String()
+
+		if _, ok := intfTypeNames[intf.Type()]; ok && s == "" {
+			t.Errorf("%#08x: empty name for known type %#x", id, int(intf.Type()))
+		}
+	})
+}
+
+func TestIntfGoString(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+	}{
+		{0x000c0202, "eosintf.Intf(0x000c0202 /* Ethernet3/1/2 */)"},
+		{0xcc000001, "eosintf.Intf(0xcc000001 /* fwd1 */)"},
+	}
+
+	for _, tc := range tt {
+		if got := fmt.Sprintf("%#v", Intf(tc.input)); got != tc.want {
+			t.Errorf("unexpected %%#v output (want %q, got %q)", tc.want, got)
+		}
+	}
+}
+
+func TestFromInt(t *testing.T) {
+	valid := []int{0, 0x000c0202}
+	invalid := []int{-1}
+	if strconv.IntSize == 64 {
+		max := int64(0xffffffff)
+		valid = append(valid, int(max))
+		invalid = append(invalid, int(max+1))
+	}
+
+	for _, v := range valid {
+		got, err := FromInt(v)
+		if err != nil {
+			t.Errorf("%#08x: unexpected error: %v", v, err)
+			continue
+		}
+		if got != Intf(v) {
+			t.Errorf("unexpected interface id (want %#08x, got %#08x)", v, int(got))
+		}
+	}
+
+	for _, v := range invalid {
+		if got, err := FromInt(v); err == nil {
+			t.Errorf("%d: expected error, got %#08x", v, int(got))
+		}
+	}
+}
+
+func TestIntfTypeOnly(t *testing.T) {
+	tt := []struct {
+		input string
+		want  string
+	}{
+		{"Ethernet3/1/2", "Ethernet"},
+		{"Port-Channel10", "Port-Channel"},
+		{"Vlan100", "Vlan0"},
+		{"Management1/1", "Management0"},
+		{"Cpu", "Cpu"},
+	}
+
+	for _, tc := range tt {
+		i := MustParseIntf(tc.input)
+		got := i.TypeOnly()
+		if got.String() != tc.want {
+			t.Errorf("%s: unexpected type only name (want %q, got %q)", tc.input, tc.want, got)
+		}
+		if got.Type() != i.Type() || got.RawPort() != 0 {
+			t.Errorf("%s: unexpected type only id %#08x", tc.input, int(got))
+		}
+	}
+}
+
+func TestIntfIs(t *testing.T) {
+	i := MustParseIntf("Ethernet3/1/2")
+
+	if !i.Is(TypeEthernet) {
+		t.Error("expected Is(TypeEthernet)")
+	}
+	if i.Is(TypePeerEthernet) {
+		t.Error("unexpected Is(TypePeerEthernet)")
+	}
+
+	if !i.IsAny(TypeVlan, TypeEthernet) {
+		t.Error("expected IsAny(TypeVlan, TypeEthernet)")
+	}
+	if i.IsAny(TypeVlan, TypePortChan) {
+		t.Error("unexpected IsAny(TypeVlan, TypePortChan)")
+	}
+	if i.IsAny() {
+		t.Error("unexpected IsAny()")
+	}
+}
+
+func TestIntfIsLAG(t *testing.T) {
+	tt := []struct {
+		input int
+		want  bool
+	}{
+		{0x0e00000a, true},  // Port-Channel10
+		{0x1200000a, true},  // PeerPort-Channel10
+		{0x1a000001, true},  // mlag1
+		{0x20000001, true},  // Mlag1
+		{0x00000001, false}, // Ethernet1
+		{0x02000064, false}, // Vlan100
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.input).IsLAG(); got != tc.want {
+			t.Errorf("%s: unexpected IsLAG (want %v, got %v)", Intf(tc.input), tc.want, got)
+		}
+	}
+}
+
+func TestIntfTypeIsPhysical(t *testing.T) {
+	tt := []struct {
+		input    IntfType
+		physical bool
+		logical  bool
+	}{
+		{TypeEthernet, true, false},
+		{TypePeerEthernet, true, false},
+		{TypeMgmt, true, false},
+		{TypeFabric, true, false},
+		{TypeVlan, false, true},
+		{TypePortChan, false, true},
+		{TypeVXLAN, false, true},
+		{TypeCPU, false, false},
+		{TypeDefaultEthSwitchedPort, false, false},
+	}
+
+	for _, tc := range tt {
+		if got := tc.input.IsPhysical(); got != tc.physical {
+			t.Errorf("%s: unexpected IsPhysical (want %v, got %v)", tc.input, tc.physical, got)
+		}
+
+		if got := tc.input.IsLogical(); got != tc.logical {
+			t.Errorf("%s: unexpected IsLogical (want %v, got %v)", tc.input, tc.logical, got)
+		}
+	}
+}
+
+func TestIntfTypeIsInternal(t *testing.T) {
+	tt := []struct {
+		input IntfType
+		want  bool
+	}{
+		{TypeMlag, true},
+		{TypeMLAG, false},
+		{TypeCPU, true},
+		{TypeFwd, true},
+		{TypeEthernet, false},
+		{TypeVlan, false},
+	}
+
+	for _, tc := range tt {
+		if got := tc.input.IsInternal(); got != tc.want {
+			t.Errorf("%s: unexpected IsInternal (want %v, got %v)", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestIntfTypeClassified(t *testing.T) {
+	for typ := range intfTypeNames {
+		n := 0
+		for _, ok := range []bool{typ.IsPhysical(), typ.IsLogical(), typ.IsInternal()} {
+			if ok {
+				n++
+			}
+		}
+		if n != 1 {
+			t.Errorf("%s is in %d of physical, logical and internal (want 1)", typ, n)
+		}
+	}
+}
+
+func TestDecodeNames(t *testing.T) {
+	ids := []int{0x000c0202, 0x00000001, 0x02000064, 0x0c000000, 0x74000005}
+
+	got := DecodeNames(ids)
+	if len(got) != len(ids) {
+		t.Fatalf("unexpected number of names (want %d, got %d)", len(ids), len(got))
+	}
+
+	for k, id := range ids {
+		if want := Intf(id).String(); got[k] != want {
+			t.Errorf("%#08x: unexpected name (want %q, got %q)", id, want, got[k])
+		}
+	}
+}
+
+var benchIDs = func() []int {
+	ids := make([]int, 4096)
+	for k := range ids {
+		ids[k] = 0x000c0000 | k
+	}
+	return ids
+}()
+
+func BenchmarkDecodeNames(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		DecodeNames(benchIDs)
+	}
+}
+
+func BenchmarkDecodeNamesLoop(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		names := make([]string, len(benchIDs))
+		for k, id := range benchIDs {
+			names[k] = Intf(id).String()
+		}
+	}
+}
+
+func TestIntfCachedString(t *testing.T) {
+	for _, id := range []int{0x000c0202, 0x02000064} {
+		want := Intf(id).String()
+		for n := 0; n < 2; n++ {
+			if got := Intf(id).CachedString(); got != want {
+				t.Errorf("%#08x: unexpected cached name (want %q, got %q)", id, want, got)
+			}
+		}
+	}
+}
+
+func BenchmarkIntfCachedString(b *testing.B) {
+	b.ReportAllocs()
+	intf := Intf(0x000c0202)
+	for n := 0; n < b.N; n++ {
+		_ = intf.CachedString()
+	}
+}
+
+func BenchmarkIntfUncachedString(b *testing.B) {
+	b.ReportAllocs()
+	intf := Intf(0x000c0202)
+	for n := 0; n < b.N; n++ {
+		_ = intf.String()
+	}
+}
+
+func BenchmarkIntfPortVlan(b *testing.B) {
+	b.ReportAllocs()
+	intf := Intf(0x02000064) // Vlan100
+	for n := 0; n < b.N; n++ {
+		_ = intf.Port()
+	}
+}
+
+func BenchmarkIntfPortEthernet(b *testing.B) {
+	b.ReportAllocs()
+	intf := Intf(0x000c0202) // Ethernet3/1/2
+	for n := 0; n < b.N; n++ {
+		_ = intf.Port()
+	}
+}
+
+// benchIntfs covers a multi-number type, a single-number type and an unknown
+// type, which falls back to the raw port.
+var benchIntfs = []struct {
+	name string
+	intf Intf
+}{
+	{"Ethernet", Intf(0x000c0202)}, // Ethernet3/1/2
+	{"Vlan", Intf(0x02000064)},     // Vlan100
+	{"Unknown", Intf(0x7e000001)},  // type 0x3f
+}
+
+func BenchmarkString(b *testing.B) {
+	for _, bc := range benchIntfs {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = bc.intf.String()
+			}
+		})
+	}
+}
+
+func BenchmarkPort(b *testing.B) {
+	for _, bc := range benchIntfs {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = bc.intf.Port()
+			}
+		})
+	}
+}
+
+func TestIntfAppendFormat(t *testing.T) {
+	b := []byte("interface ")
+	for _, id := range []int{0x000c0202, 0x02000064, 0x0c000000} {
+		want := "interface " + Intf(id).String()
+		if got := string(Intf(id).AppendFormat(b)); got != want {
+			t.Errorf("%#08x: unexpected output (want %q, got %q)", id, want, got)
+		}
+	}
+}
+
+func TestIntfSameSlot(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{"Ethernet3/1/1", "Ethernet3/2/1", true},
+		{"Ethernet3/1/1", "Management3/1", true},
+		{"Ethernet1", "Ethernet2", true},
+		{"Ethernet3/1/1", "Ethernet4/1/1", false},
+		{"Ethernet3/1/1", "Vlan3", false},
+		{"Vlan3", "Ethernet3/1/1", false},
+		{"Vlan3", "Vlan3", false},
+	}
+
+	for _, tc := range tt {
+		if got := MustParseIntf(tc.a).SameSlot(MustParseIntf(tc.b)); got != tc.want {
+			t.Errorf("%s, %s: unexpected SameSlot (want %v, got %v)", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
+
+func TestIntfFormatSep(t *testing.T) {
+	tt := []struct {
+		input int
+		sep   string
+		want  string
+	}{
+		{0x000c0202, "_", "Ethernet3_1_2"},
+		{0x000c0202, "-", "Ethernet3-1-2"},
+		{0x000c0202, "/", "Ethernet3/1/2"},
+		{0x000c0202, "", "Ethernet312"},
+		{0x04000201, "_", "Management1_1"},
+		{0x02000064, "_", "Vlan100"},
+		{0x0c000000, "_", "Cpu"},
+		{0x74000005, "_", "DynamicTunnel5.0"},
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.input).FormatSep(tc.sep); got != tc.want {
+			t.Errorf("%#08x %q: unexpected name (want %q, got %q)", tc.input, tc.sep, tc.want, got)
+		}
+	}
+}
+
+func TestIntfIsBreakout(t *testing.T) {
+	tt := []struct {
+		input string
+		want  bool
+	}{
+		{"Ethernet1/1", true},
+		{"Ethernet49/4", true},
+		{"Ethernet1", false},
+		{"Ethernet3/1/2", false},
+		{"Management1/1", false},
+		{"Vlan1", false},
+	}
+
+	for _, tc := range tt {
+		if got := MustParseIntf(tc.input).IsBreakout(); got != tc.want {
+			t.Errorf("%s: unexpected IsBreakout (want %v, got %v)", tc.input, tc.want, got)
+		}
+	}
+}
+
+// TestIntfAllTypes is a golden table with at least one ID for every known
+// type.  Entries with a note have an unconfirmed layout and are still checked
+// against the current decoding; only undecoded layouts (see UndecodedTypes)
+// are skipped.
+func TestIntfAllTypes(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+		note  string
+	}{
+		{0x000c0202, "Ethernet3/1/2", ""},
+		{0x02000064, "Vlan100", ""},
+		{0x04000201, "Management1/1", ""},
+		{0x06000000, "Loopback0", ""},
+		{0x06000001, "Loopback1", ""},
+		{0x08000000, "Null0", ""},
+		{0x0a000401, "Internal2/1", ""},
+		{0x0c000000, "Cpu", ""},
+		{0x0e00000a, "Port-Channel10", ""},
+		{0x100c0202, "PeerEthernet3/1/2", ""},
+		{0x1200000a, "PeerPort-Channel10", ""},
+		{0x14fff004, "Test4095/4", ""},
+		{0x16000000, "Switch", ""},
+		{0x18000000, "l2QuerierLink", ""},
+		{0x1a0001ff, "mlag511", ""},
+		{0x1e000064, "Tunnel100", ""},
+		{0x20000ffe, "Mlag4094", ""},
+		{0x2a000000, "DefaultTestPort", ""},
+		{0x2c000000, "DefaultEthManagementPort", ""},
+		{0x2e0000ff, "DefaultEthSwitchedPort255", ""},
+		{0x30000000, "DefaultEthInternalPort", ""},
+		{0x32000001, "host1", ""},
+		{0x44000000, "DefaultEthDataLinkPort", ""},
+		{0x70000001, "Vxlan1", ""},
+		{0x7200000a, "Gre10", ""},
+		{0x74000005, "DynamicTunnel5.0", "width assumed to match Vxlan and Gre"},
+		{0x76000001, "Pseudowire1", "width assumed to be 16 bits"},
+		{0x78000001, "tunnelTap1", "width assumed to be 16 bits"},
+		{0x90000001, "Fabric1", "layout unknown, renders the raw port number"},
+		{0x9e000001, "Register1", ""},
+		{0xb4000000, "OpenFlowRouter", ""},
+		{0xc6000001, "T2Recirc1", "width assumed to be 16 bits"},
+		{0xcc000000, "fwd0", ""},
+		{0xcc000001, "fwd1", ""},
+	}
+
+	seen := make(map[IntfType]bool)
+	for _, tc := range tt {
+		seen[NewFromRaw(tc.input).Type()] = true
+
+		t.Run(tc.want, func(t *testing.T) {
+			intf := NewFromRaw(tc.input)
+			if layoutFor(intf.Type()).undecoded {
+				t.Skip("TODO: " + tc.note)
+			}
+
+			if got := intf.String(); got != tc.want {
+				t.Errorf("unexpected interface name (want %q, got %q)", tc.want, got)
+			}
+		})
+	}
+
+	for typ := range intfTypeNames {
+		if !seen[typ] {
+			t.Errorf("no golden entry for %s (%#x)", typ, int(typ))
+		}
+	}
+}
+
+func TestIntfTypeHasPort(t *testing.T) {
+	tt := []struct {
+		input IntfType
+		want  bool
+	}{
+		{TypeEthernet, true},
+		{TypeVlan, true},
+		{TypeFwd, true},
+		{TypeCPU, false},
+		{TypeSwitch, false},
+		{TypeOpenFlowRouter, false},
+		{TypeDefaultEthMgmtPort, true},
+		{TypeDefaultTestPort, false},
+	}
+
+	for _, tc := range tt {
+		if got := tc.input.HasPort(); got != tc.want {
+			t.Errorf("%s: unexpected HasPort (want %v, got %v)", tc.input, tc.want, got)
+		}
+	}
+
+	// Types without a port must always render an empty port.
+	for typ := range intfTypeNames {
+		if port := Intf(int(typ)<<25 | 0x1ffffff).Port(); !typ.HasPort() && port != "" {
+			t.Errorf("%s: HasPort is false but rendered port %q", typ, port)
+		}
+	}
+}
+
+func TestIntfRaw(t *testing.T) {
+	for _, v := range []uint32{0x000c0202, 0x90000001, 0xffffffff} {
+		intf := NewFromRaw(v)
+		if got := intf.Raw(); got != v {
+			t.Errorf("unexpected raw id (want %#08x, got %#08x)", v, got)
+		}
+	}
+}
+
+func TestIntfTypeUnsigned(t *testing.T) {
+	intf := Intf(-1)
+	if got := intf.Type(); got != 0x7f {
+		t.Errorf("unexpected type (want %#x, got %#x)", 0x7f, int(got))
+	}
+
+	if got := intf.RawPort(); got != 0x1ffffff {
+		t.Errorf("unexpected raw port (want %#x, got %#x)", 0x1ffffff, got)
+	}
+}
+
+// TestIntfHighTypeBit checks types with the top bit of the 32-bit ID set
+// (types >= 0x40) decode the same whether the ID was positive or came from a
+// negative int32.
+func TestIntfHighTypeBit(t *testing.T) {
+	tt := []struct {
+		input Intf
+		want  string
+	}{
+		{NewFromRaw(0x90000001), "Fabric1"},
+		{Intf(int32(-0x6fffffff)), "Fabric1"}, // 0x90000001 as an int32
+		{NewFromRaw(0x91000000), "Fabric16777216"},
+		{Intf(int32(-0x33ffffff)), "fwd1"}, // 0xcc000001 as an int32
+	}
+
+	for _, tc := range tt {
+		if typ := tc.input.Type(); typ != TypeFabric && typ != TypeFwd {
+			t.Errorf("%d: unexpected type %#x", int(tc.input), int(typ))
+		}
+
+		if got := tc.input.String(); got != tc.want {
+			t.Errorf("%d: unexpected interface name (want %q, got %q)", int(tc.input), tc.want, got)
+		}
+	}
+
+	if got := Intf(-1).GoString(); got != "eosintf.Intf(0xffffffff /* UNKNOWN33554431 */)" {
+		t.Errorf("unexpected GoString for -1: %q", got)
+	}
+}
+
+func TestIntfSlot(t *testing.T) {
+	tt := []struct {
+		input string
+		slot  int
+		ok    bool
+	}{
+		{"Ethernet3/1/2", 3, true},
+		{"Ethernet1", 0, true},
+		{"Management2/1", 2, true},
+		{"Test7/1", 7, true},
+		{"Vlan100", 0, false},
+		{"Cpu", 0, false},
+	}
+
+	for _, tc := range tt {
+		slot, ok := MustParseIntf(tc.input).Slot()
+		if slot != tc.slot || ok != tc.ok {
+			t.Errorf("%s: unexpected slot (want %d, %v, got %d, %v)", tc.input, tc.slot, tc.ok, slot, ok)
+		}
+	}
+}
+
+// TestIntfZero pins the behavior of the zero value.
+func TestIntfZero(t *testing.T) {
+	var zero Intf
+	if !zero.IsZero() {
+		t.Error("zero value is not IsZero")
+	}
+
+	if Intf(0x00000001).IsZero() {
+		t.Error("Ethernet1 is IsZero")
+	}
+
+	if got := zero.String(); got != "Ethernet" {
+		t.Errorf("unexpected zero value name (want %q, got %q)", "Ethernet", got)
+	}
+
+	// The zero value is not an interface and no name parses to it.
+	if zero.IsValid() {
+		t.Error("zero value is valid")
+	}
+	for _, name := range []string{"", "Ethernet", "Et", "Ethernet0", "Ethernet0/0", "Port-Channel", "Po0"} {
+		if got, err := ParseIntf(name); err == nil {
+			t.Errorf("%q: expected parse error, got %#08x", name, int(got))
+		}
+	}
+
+	// It still encodes, as the raw ID.
+	b, err := zero.MarshalJSON()
+	if err != nil || string(b) != "0" {
+		t.Errorf("unexpected zero value json (want %q, got %q, err %v)", "0", b, err)
+	}
+}
+
+func TestIntfPortString(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+		err   error
+	}{
+		{0x000c0202, "3/1/2", nil},          // Ethernet3/1/2
+		{0x0c000000, "", nil},               // Cpu
+		{0x90000001, "1", ErrUndecodedPort}, // Fabric1
+		{0x78000001, "1", nil},              // tunnelTap1
+		{0x1c000001, "1", ErrUnknownType},   // type 0x0e
+	}
+
+	for _, tc := range tt {
+		got, err := Intf(tc.input).PortString()
+		if got != tc.want || err != tc.err {
+			t.Errorf("%#08x: unexpected port (want %q, %v, got %q, %v)", tc.input, tc.want, tc.err, got, err)
+		}
+	}
+}
+
+// TestIntfFwd documents the assumed single bit fwd index: bits above bit 0
+// are ignored.
+func TestIntfFwd(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+	}{
+		{0xcc000000, "fwd0"},
+		{0xcc000001, "fwd1"},
+		{0xcc000002, "fwd0"},
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.input).String(); got != tc.want {
+			t.Errorf("%#08x: unexpected interface name (want %q, got %q)", tc.input, tc.want, got)
+		}
+	}
+}
+
+// TestIntfTunnelTap documents the assumed 16 bit tunnelTap index: bits above
+// bit 15 are ignored.
+func TestIntfTunnelTap(t *testing.T) {
+	tt := []struct {
+		input int
+		want  string
+	}{
+		{0x78000001, "tunnelTap1"},
+		{0x7800ffff, "tunnelTap65535"},
+		{0x78010001, "tunnelTap1"},
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.input).String(); got != tc.want {
+			t.Errorf("%#08x: unexpected interface name (want %q, got %q)", tc.input, tc.want, got)
+		}
+	}
+
+	if got, err := ParseIntf("tunnelTap65536"); err == nil {
+		t.Errorf("expected error, got %#08x", int(got))
+	}
+}
+
+func TestTypePsuedowireAlias(t *testing.T) {
+	if TypePsuedowire != TypePseudowire {
+		t.Errorf("TypePsuedowire (%#x) != TypePseudowire (%#x)", int(TypePsuedowire), int(TypePseudowire))
+	}
+
+	if got := TypePsuedowire.String(); got != "Pseudowire" {
+		t.Errorf("unexpected name (want %q, got %q)", "Pseudowire", got)
+	}
+}
+
+func TestIntfTypeIsTunnel(t *testing.T) {
+	want := map[IntfType]bool{
+		TypeTunnel:        true,
+		TypeVXLAN:         true,
+		TypeGRE:           true,
+		TypeDynamicTunnel: true,
+		TypeTunnelTap:     true,
+		TypePseudowire:    true,
+	}
+
+	for typ := range intfTypeNames {
+		if got := typ.IsTunnel(); got != want[typ] {
+			t.Errorf("%s: unexpected IsTunnel (want %v, got %v)", typ, want[typ], got)
+		}
+	}
+}
+
+func TestKnownTypes(t *testing.T) {
+	got := KnownTypes()
+	if len(got) != len(intfTypeNames) {
+		t.Fatalf("unexpected number of types (want %d, got %d)", len(intfTypeNames), len(got))
+	}
+
+	for k, typ := range got {
+		if k > 0 && got[k-1] >= typ {
+			t.Errorf("types not sorted: %s (%#02x) after %s (%#02x)", typ, int(typ), got[k-1], int(got[k-1]))
+		}
+		if typ.Name() != intfTypeNames[typ] {
+			t.Errorf("unexpected name (want %q, got %q)", intfTypeNames[typ], typ.Name())
+		}
+	}
+
+	if got[0] != TypeEthernet {
+		t.Errorf("unexpected first type (want %s, got %s)", TypeEthernet, got[0])
+	}
+}
+
+func TestIsManagement(t *testing.T) {
+	tt := []struct {
+		input string
+		want  bool
+	}{
+		{"Management0", true},
+		{"Management1/1", true},
+		{"Ethernet1", false},
+		{"DefaultEthManagementPort", false},
+	}
+
+	for _, tc := range tt {
+		i := MustParseIntf(tc.input)
+		if got := i.IsManagement(); got != tc.want {
+			t.Errorf("%s: unexpected IsManagement (want %v, got %v)", tc.input, tc.want, got)
+		}
+		if got := i.Type().IsManagement(); got != tc.want {
+			t.Errorf("%s: unexpected type IsManagement (want %v, got %v)", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestIntfTypeIsPeer(t *testing.T) {
+	for typ := range intfTypeNames {
+		want := typ == TypePeerEthernet || typ == TypePeerPortChan
+		if got := typ.IsPeer(); got != want {
+			t.Errorf("%s: unexpected IsPeer (want %v, got %v)", typ, want, got)
+		}
+	}
+}
+
+func TestIntfPeer(t *testing.T) {
+	tt := []struct {
+		input string
+		want  string
+	}{
+		{"Ethernet3/1/2", "PeerEthernet3/1/2"},
+		{"PeerEthernet3/1/2", "Ethernet3/1/2"},
+		{"Port-Channel10", "PeerPort-Channel10"},
+		{"PeerPort-Channel10", "Port-Channel10"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			i := MustParseIntf(tc.input)
+			got, ok := i.Peer()
+			if !ok {
+				t.Fatal("expected peer")
+			}
+
+			if got.String() != tc.want {
+				t.Errorf("unexpected peer (want %q, got %q)", tc.want, got)
+			}
+
+			if back, _ := got.Peer(); back != i {
+				t.Errorf("unexpected round-trip (want %#08x, got %#08x)", int(i), int(back))
+			}
+		})
+	}
+
+	for _, input := range []string{"Vlan100", "Mlag1", "Management1"} {
+		if got, ok := MustParseIntf(input).Peer(); ok {
+			t.Errorf("%s: unexpected peer %s", input, got)
+		}
+	}
+}
+
+func TestIntfDebug(t *testing.T) {
+	tt := []struct {
+		input int
+		want  string
+	}{
+		{0x000c0202, "type=0x00(Ethernet) raw=0x000c0202 slot=3 module=1 port=2"},
+		{0x04000201, "type=0x02(Management) raw=0x00000201 slot=1 port=1"},
+		{0x02000064, "type=0x01(Vlan) raw=0x00000064 port=100"},
+		{0x0c000000, "type=0x06(Cpu) raw=0x00000000"},
+	}
+
+	for _, tc := range tt {
+		if got := Intf(tc.input).Debug(); got != tc.want {
+			t.Errorf("%#08x: unexpected debug output (want %q, got %q)", tc.input, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package eosintf
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestLayoutRoundTrip(t *testing.T) {
+	for typ := range layouts {
+		for _, port := range []int{0, 1, 0x1ffffff, 0x0aaaaaa, 0x1555555} {
+			want := Intf(int(typ)<<25 | port&portMask(typ))
+
+			got, err := newIntf(typ, unpackPort(typ, want.RawPort())...)
+			if !want.IsValid() {
+				// A zero port of a type numbered from 1.
+				if err == nil {
+					t.Errorf("%s %#08x: expected encode error, got %#08x", typ, int(want), int(got))
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s %#08x: unexpected encode error: %v", typ, int(want), err)
+				continue
+			}
+
+			if got != want {
+				t.Errorf("%s: encode(decode(%#08x)) = %#08x", typ, int(want), int(got))
+			}
+		}
+	}
+}
+
+func TestLayoutParseRoundTrip(t *testing.T) {
+	for typ := range layouts {
+		want := Intf(int(typ)<<25 | 0x1555555&portMask(typ))
+
+		got, err := ParseIntf(want.String())
+		if err != nil {
+			t.Errorf("%s %#08x: unexpected parse error: %v", typ, int(want), err)
+			continue
+		}
+
+		if got != want {
+			t.Errorf("%s: ParseIntf(%q) = %#08x, want %#08x", typ, want, int(got), int(want))
+		}
+	}
+}
+
+// checkRoundTrip checks that the raw ID renders as name and that name parses
+// back to the same ID.
+func checkRoundTrip(t *testing.T, id uint32, name string) {
+	t.Helper()
+
+	intf := NewFromRaw(id)
+	if got := intf.String(); got != name {
+		t.Errorf("unexpected interface name (want %q, got %q)", name, got)
+	}
+
+	got, err := ParseIntf(name)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if got != intf {
+		t.Errorf("unexpected round-trip id (want %#08x, got %#08x)", id, got.Raw())
+	}
+}
+
+// constructors builds an Intf of each type in the layout table from its
+// components.  Types with an exported constructor use it; the rest go through
+// the encoder directly.  A type added to layouts must be added here too.
+var constructors = map[IntfType]func(n []int) (Intf, error){
+	TypeEthernet:     func(n []int) (Intf, error) { return NewEthernet(n[0], n[1], n[2]) },
+	TypePeerEthernet: func(n []int) (Intf, error) { return NewPeerEthernet(n[0], n[1], n[2]) },
+	TypeTest:         func(n []int) (Intf, error) { return NewTest(n[0], n[1]) },
+	TypeTunnel:       func(n []int) (Intf, error) { return NewTunnel(n[0]) },
+	TypeVXLAN:        func(n []int) (Intf, error) { return NewVxlan(n[0]) },
+	TypeGRE:          func(n []int) (Intf, error) { return NewGRE(n[0]) },
+	TypeMLAG:         func(n []int) (Intf, error) { return NewMLAG(n[0]) },
+	TypePortChan:     func(n []int) (Intf, error) { return NewPortChannel(n[0]) },
+	TypePeerPortChan: func(n []int) (Intf, error) { return NewPeerPortChannel(n[0]) },
+
+	TypeFabric:                 encoder(TypeFabric),
+	TypeT2Recirc:               encoder(TypeT2Recirc),
+	TypeMgmt:                   encoder(TypeMgmt),
+	TypeInternal:               encoder(TypeInternal),
+	TypeFwd:                    encoder(TypeFwd),
+	TypeDefaultEthSwitchedPort: encoder(TypeDefaultEthSwitchedPort),
+	TypeMlag:                   encoder(TypeMlag),
+	TypeVlan:                   encoder(TypeVlan),
+	TypeLoopback:               encoder(TypeLoopback),
+	TypeNull:                   encoder(TypeNull),
+	TypeHost:                   encoder(TypeHost),
+	TypeRegister:               encoder(TypeRegister),
+	TypeDynamicTunnel:          encoder(TypeDynamicTunnel),
+	TypePseudowire:             encoder(TypePseudowire),
+	TypeTunnelTap:              encoder(TypeTunnelTap),
+	TypeCPU:                    encoder(TypeCPU),
+	TypeSwitch:                 encoder(TypeSwitch),
+	TypeL2QuerierLink:          encoder(TypeL2QuerierLink),
+	TypeDefaultTestPort:        encoder(TypeDefaultTestPort),
+	TypeDefaultEthMgmtPort:     encoder(TypeDefaultEthMgmtPort),
+	TypeDefaultEthInternalPort: encoder(TypeDefaultEthInternalPort),
+	TypeDefaultEthDataLinkPort: encoder(TypeDefaultEthDataLinkPort),
+	TypeOpenFlowRouter:         encoder(TypeOpenFlowRouter),
+}
+
+func encoder(typ IntfType) func(n []int) (Intf, error) {
+	return func(n []int) (Intf, error) { return newIntf(typ, n...) }
+}
+
+// TestLayoutRandomRoundTrip builds interfaces from random in-range components
+// of every type in the layout table and checks they survive rendering and
+// parsing unchanged.  The types are visited in order so a failure reproduces
+// from the fixed seed.
+func TestLayoutRandomRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, typ := range KnownTypes() {
+		l, ok := layouts[typ]
+		if !ok {
+			continue
+		}
+		construct, ok := constructors[typ]
+		if !ok {
+			t.Errorf("%s: no constructor", typ)
+			continue
+		}
+
+		for n := 0; n < 100; n++ {
+			nums := make([]int, len(l.fields))
+			for k, f := range l.fields {
+				nums[k] = r.Intn(f.max() + 1)
+			}
+			// Keep types numbered from 1 off the all-zero number.
+			if l.fromOne() {
+				last := len(nums) - 1
+				nums[last] = 1 + r.Intn(l.fields[last].max())
+			}
+
+			want, err := construct(nums)
+			if err != nil {
+				t.Errorf("%s %v: unexpected encode error: %v", typ, nums, err)
+				continue
+			}
+
+			name := want.String()
+			got, err := ParseIntf(name)
+			if err != nil {
+				t.Errorf("%s %v: unexpected parse error: %v", typ, nums, err)
+				continue
+			}
+
+			if got != want {
+				t.Errorf("%s %v: ParseIntf(%q) = %#08x, want %#08x", typ, nums, name, int(got), int(want))
+			}
+			if got.String() != name {
+				t.Errorf("%s %v: unexpected round-trip name (want %q, got %q)", typ, nums, name, got)
+			}
+		}
+	}
+}
+
+// TestUndecodedTypes reports the types whose layout is still unknown.  These
+// are logged rather than failed on as they are the reverse-engineering
+// backlog.
+func TestUndecodedTypes(t *testing.T) {
+	for _, typ := range UndecodedTypes() {
+		t.Logf("%s (%#02x) is not decoded", typ, int(typ))
+
+		if _, err := Intf(int(typ)<<typeShift | 1).PortString(); err != ErrUndecodedPort {
+			t.Errorf("%s: unexpected PortString error (want %v, got %v)", typ, ErrUndecodedPort, err)
+		}
+	}
+
+	for _, typ := range []IntfType{TypeEthernet, TypeVlan, TypeCPU} {
+		for _, u := range UndecodedTypes() {
+			if u == typ {
+				t.Errorf("%s: unexpectedly undecoded", typ)
+			}
+		}
+	}
+}
+
+func TestIntfTypeLimits(t *testing.T) {
+	tt := []struct {
+		input              IntfType
+		slot, module, port int
+	}{
+		{TypeEthernet, 127, 511, 511},
+		{TypePeerEthernet, 127, 511, 511},
+		{TypeMgmt, 511, 0, 511},
+		{TypeInternal, 511, 0, 511},
+		{TypeTest, 4095, 0, 4095},
+		{TypeVlan, 0, 0, 4095},
+		{TypePortChan, 0, 0, 8191},
+		{TypeVXLAN, 0, 0, 65535},
+		{TypeFwd, 0, 0, 1},
+		{TypeCPU, 0, 0, 0},
+	}
+
+	for _, tc := range tt {
+		if got := tc.input.MaxSlot(); got != tc.slot {
+			t.Errorf("%s: unexpected MaxSlot (want %d, got %d)", tc.input, tc.slot, got)
+		}
+		if got := tc.input.MaxModule(); got != tc.module {
+			t.Errorf("%s: unexpected MaxModule (want %d, got %d)", tc.input, tc.module, got)
+		}
+		if got := tc.input.MaxPort(); got != tc.port {
+			t.Errorf("%s: unexpected MaxPort (want %d, got %d)", tc.input, tc.port, got)
+		}
+	}
+
+	// The limits and Components both follow the layout.
+	for _, typ := range KnownTypes() {
+		_, _, _, ok := NewFromRaw(uint32(typ) << typeShift).Components()
+		if hasSlot := typ.MaxSlot() > 0; hasSlot != ok {
+			t.Errorf("%s: MaxSlot %d disagrees with Components ok %v", typ, typ.MaxSlot(), ok)
+		}
+	}
+}
+
+func TestIntfTypePortCount(t *testing.T) {
+	tt := []struct {
+		input IntfType
+		want  int
+	}{
+		{TypeEthernet, 512},
+		{TypeMgmt, 512},
+		{TypeTest, 4096},
+		{TypeVlan, 4096},
+		{TypePortChan, 8192},
+		{TypeVXLAN, 65536},
+		{TypeFwd, 2},
+		{TypeCPU, 1},
+	}
+
+	for _, tc := range tt {
+		if got := tc.input.PortCount(); got != tc.want {
+			t.Errorf("%s: unexpected PortCount (want %d, got %d)", tc.input, tc.want, got)
+		}
+	}
+}
+
+// TestIntfTypeLimitsParse checks the limits agree with the parser at the
+// boundary of each type's port field.
+func TestIntfTypeLimitsParse(t *testing.T) {
+	for typ := range intfTypeNames {
+		if !typ.HasPort() {
+			continue
+		}
+
+		name := typ.String() + strconv.Itoa(typ.MaxPort()) + layoutFor(typ).suffix
+		if _, err := ParseIntf(name); err != nil {
+			t.Errorf("%s: unexpected error at max port: %v", typ, err)
+		}
+
+		name = typ.String() + strconv.Itoa(typ.MaxPort()+1) + layoutFor(typ).suffix
+		if _, err := ParseIntf(name); err == nil {
+			t.Errorf("%s: expected error past max port", typ)
+		}
+	}
+}
+
+// TestLayoutShowZero is a golden table of how each type renders when all of
+// its port bits are zero.
+func TestLayoutShowZero(t *testing.T) {
+	tt := []struct {
+		typ  IntfType
+		want string
+	}{
+		{TypeEthernet, "Ethernet"},
+		{TypePeerEthernet, "PeerEthernet"},
+		{TypePortChan, "Port-Channel"},
+		{TypeVXLAN, "Vxlan"},
+		{TypeCPU, "Cpu"},
+		{TypeMgmt, "Management0"},
+		{TypeVlan, "Vlan0"},
+		{TypeLoopback, "Loopback0"},
+		{TypeNull, "Null0"},
+		{TypeTunnel, "Tunnel0"},
+		{TypeFwd, "fwd0"},
+		{TypeDynamicTunnel, "DynamicTunnel0.0"},
+		{TypeHost, "host0"},
+		{TypeRegister, "Register0"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			id := uint32(tc.typ) << typeShift
+
+			// Types numbered from 1 have no zero: the bare name is only
+			// what the invalid ID renders as.
+			if layoutFor(tc.typ).fromOne() {
+				intf := NewFromRaw(id)
+				if got := intf.String(); got != tc.want {
+					t.Errorf("unexpected interface name (want %q, got %q)", tc.want, got)
+				}
+				if intf.IsValid() {
+					t.Error("unexpected valid zero port")
+				}
+				if got, err := ParseIntf(tc.want); err == nil {
+					t.Errorf("expected parse error, got %#08x", int(got))
+				}
+				return
+			}
+
+			checkRoundTrip(t, id, tc.want)
+		})
+	}
+}
+
+func TestLayoutHost(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+	}{
+		{0x32000000, "host0"},
+		{0x32000001, "host1"},
+		{0x32000002, "host2"},
+		{0x32000fff, "host4095"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			checkRoundTrip(t, tc.input, tc.want)
+		})
+	}
+
+	if got, err := ParseIntf("host4096"); err == nil {
+		t.Errorf("expected error, got %#08x", int(got))
+	}
+}
+
+// TestLayoutOpenFlowRouter checks OpenFlowRouter is handled as a singleton:
+// it renders without a number, rejects a number when parsing and treats any
+// port bits as invalid.
+func TestLayoutOpenFlowRouter(t *testing.T) {
+	intf := NewFromRaw(0xb4000000)
+	if want := "OpenFlowRouter"; intf.String() != want {
+		t.Errorf("unexpected interface name (want %q, got %q)", want, intf)
+	}
+	if !intf.IsValid() {
+		t.Errorf("%s: expected valid", intf)
+	}
+
+	if got := NewFromRaw(0xb4000001); got.IsValid() {
+		t.Errorf("%#08x: expected invalid", int(got))
+	}
+
+	if got, err := ParseIntf("OpenFlowRouter1"); err == nil {
+		t.Errorf("expected error, got %#08x", int(got))
+	}
+}
+
+func TestLayoutRegister(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+	}{
+		{0x9e000000, "Register0"},
+		{0x9e000001, "Register1"},
+		{0x9e00000a, "Register10"},
+		{0x9e000fff, "Register4095"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			checkRoundTrip(t, tc.input, tc.want)
+		})
+	}
+}
+
+// TestLayoutDefaultEth checks each DefaultEth* port profile decodes the same
+// 8 bit index, with index zero rendered as the bare profile name.
+func TestLayoutDefaultEth(t *testing.T) {
+	tt := []struct {
+		input uint32
+		want  string
+	}{
+		{0x2c000000, "DefaultEthManagementPort"},
+		{0x2c000001, "DefaultEthManagementPort1"},
+		{0x2e000000, "DefaultEthSwitchedPort"},
+		{0x2e0000ff, "DefaultEthSwitchedPort255"},
+		{0x30000000, "DefaultEthInternalPort"},
+		{0x30000002, "DefaultEthInternalPort2"},
+		{0x44000000, "DefaultEthDataLinkPort"},
+		{0x44000003, "DefaultEthDataLinkPort3"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			checkRoundTrip(t, tc.input, tc.want)
+		})
+	}
+
+	for _, typ := range []IntfType{TypeDefaultEthMgmtPort, TypeDefaultEthSwitchedPort, TypeDefaultEthInternalPort, TypeDefaultEthDataLinkPort} {
+		if got := typ.MaxPort(); got != 255 {
+			t.Errorf("%s: unexpected MaxPort (want 255, got %d)", typ, got)
+		}
+	}
+}
+
+// TestLayoutSwitch checks Switch and l2QuerierLink are handled as singletons
+// like OpenFlowRouter.
+func TestLayoutSwitch(t *testing.T) {
+	tt := []struct {
+		input int
+		want  string
+	}{
+		{0x16000000, "Switch"},
+		{0x18000000, "l2QuerierLink"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			intf := Intf(tc.input)
+			if intf.String() != tc.want {
+				t.Errorf("unexpected interface name (want %q, got %q)", tc.want, intf)
+			}
+			if !intf.IsValid() {
+				t.Errorf("%s: expected valid", intf)
+			}
+
+			if got := Intf(tc.input | 1); got.IsValid() {
+				t.Errorf("%#08x: expected invalid", int(got))
+			}
+
+			if got, err := ParseIntf(tc.want + "1"); err == nil {
+				t.Errorf("expected error, got %#08x", int(got))
+			}
+		})
+	}
+}
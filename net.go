@@ -0,0 +1,28 @@
+package eosintf
+
+import (
+	"net"
+	"strings"
+)
+
+// FindInterface returns the entry in ifaces whose name matches i.  The full
+// name (Ethernet3/1/2), the abbreviated name (Et3/1/2) and the lowercase
+// abbreviated name with '_' separators (et3_1_2, as the EOS kernel names
+// front-panel ports) are tried in that order.
+func FindInterface(ifaces []net.Interface, i Intf) (*net.Interface, bool) {
+	short := i.ShortString()
+	names := []string{
+		i.String(),
+		short,
+		strings.ToLower(strings.Replace(short, "/", "_", -1)),
+	}
+
+	for _, name := range names {
+		for k := range ifaces {
+			if ifaces[k].Name == name {
+				return &ifaces[k], true
+			}
+		}
+	}
+	return nil, false
+}
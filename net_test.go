@@ -0,0 +1,41 @@
+package eosintf
+
+import (
+	"net"
+	"testing"
+)
+
+func TestFindInterface(t *testing.T) {
+	ifaces := []net.Interface{
+		{Index: 1, Name: "lo"},
+		{Index: 2, Name: "Ethernet1"},
+		{Index: 3, Name: "Et2"},
+		{Index: 4, Name: "et3_1_2"},
+		{Index: 5, Name: "Vl100"},
+	}
+
+	tt := []struct {
+		input string
+		index int
+	}{
+		{"Ethernet1", 2},
+		{"Ethernet2", 3},
+		{"Ethernet3/1/2", 4},
+		{"Vlan100", 5},
+		{"Ethernet4", 0},
+	}
+
+	for _, tc := range tt {
+		got, ok := FindInterface(ifaces, MustParseIntf(tc.input))
+		if tc.index == 0 {
+			if ok {
+				t.Errorf("%s: unexpected match %q", tc.input, got.Name)
+			}
+			continue
+		}
+
+		if !ok || got.Index != tc.index {
+			t.Errorf("%s: unexpected match (want index %d, got %v, %v)", tc.input, tc.index, got, ok)
+		}
+	}
+}
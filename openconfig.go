@@ -0,0 +1,38 @@
+package eosintf
+
+import "fmt"
+
+// OpenConfigName returns the name used for the interface in
+// openconfig-interfaces paths.  EOS uses the full canonical interface names
+// (e.g. "Ethernet1", "Port-Channel10", "Vlan100") so this is the same as
+// String().
+func (i Intf) OpenConfigName() string {
+	return i.String()
+}
+
+// GNMIPathKey returns the value of the name key for the interface in gNMI
+// paths such as /interfaces/interface[name=Ethernet1].  It is the
+// OpenConfigName.
+func (i Intf) GNMIPathKey() string {
+	return i.OpenConfigName()
+}
+
+// GNMIKey returns the key map for the interface's /interfaces/interface
+// path element, in the shape of the Key field of gnmi.PathElem.
+func (i Intf) GNMIKey() map[string]string {
+	return map[string]string{"name": i.GNMIPathKey()}
+}
+
+// ParseOpenConfigName parses an openconfig-interfaces name.  Unlike ParseIntf
+// only the exact canonical name is accepted as these are used as keys.
+func ParseOpenConfigName(s string) (Intf, error) {
+	i, err := ParseIntf(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if name := i.OpenConfigName(); name != s {
+		return 0, fmt.Errorf("%q is not a canonical openconfig name (expected %q)", s, name)
+	}
+	return i, nil
+}
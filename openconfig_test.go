@@ -0,0 +1,55 @@
+package eosintf
+
+import "testing"
+
+func TestOpenConfigName(t *testing.T) {
+	tt := []struct {
+		input int
+		want  string
+	}{
+		{0x00000001, "Ethernet1"},
+		{0x000c0202, "Ethernet3/1/2"},
+		{0x0e00000a, "Port-Channel10"},
+		{0x02000064, "Vlan100"},
+		{0x04000001, "Management1"},
+		{0x04000201, "Management1/1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			intf := Intf(tc.input)
+			if got := intf.OpenConfigName(); got != tc.want {
+				t.Errorf("unexpected name (want %q, got %q)", tc.want, got)
+			}
+
+			got, err := ParseOpenConfigName(tc.want)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != intf {
+				t.Errorf("unexpected interface (want %#08x, got %#08x)", tc.input, int(got))
+			}
+		})
+	}
+}
+
+func TestParseOpenConfigNameError(t *testing.T) {
+	for _, input := range []string{"Et1", "Po10", "Ethernet0/1", "Bogus1"} {
+		if got, err := ParseOpenConfigName(input); err == nil {
+			t.Errorf("%q: expected error, got %v", input, got)
+		}
+	}
+}
+
+func TestGNMIPathKey(t *testing.T) {
+	intf := Intf(0x000c0202)
+	if want := "Ethernet3/1/2"; intf.GNMIPathKey() != want {
+		t.Errorf("unexpected path key (want %q, got %q)", want, intf.GNMIPathKey())
+	}
+
+	key := intf.GNMIKey()
+	if len(key) != 1 || key["name"] != "Ethernet3/1/2" {
+		t.Errorf("unexpected key %v", key)
+	}
+}
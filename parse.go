@@ -0,0 +1,271 @@
+package eosintf
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrEmptyName is returned when parsing an empty (or all whitespace)
+// interface name.
+var ErrEmptyName = errors.New("empty interface name")
+
+// ParseIntf parses an interface name such as "Ethernet3/1/2" or "Et3/1/2"
+// into its Intf.  It is the inverse of Intf.String() and Intf.ShortString().
+// Surrounding whitespace is ignored and type names are matched
+// case-insensitively (see TypeFromName).
+func ParseIntf(s string) (Intf, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return 0, ErrEmptyName
+	}
+
+	t, rest, ok := splitTypeName(trimmed)
+	if !ok {
+		return 0, fmt.Errorf("unknown interface type in %q", s)
+	}
+
+	// The CLI allows a space between the type and number ("Ethernet 1").
+	rest = strings.TrimLeft(rest, " ")
+
+	if suffix := layoutFor(t).suffix; suffix != "" {
+		rest = strings.TrimSuffix(rest, suffix)
+	}
+
+	nums, err := parseNums(rest)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interface name %q: %v", s, err)
+	}
+
+	i, err := newIntf(t, nums...)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interface name %q: %v", s, err)
+	}
+	return i, nil
+}
+
+// ParsePrefix parses the interface name at the start of s and returns it
+// along with the rest of the string, e.g. "Ethernet1/1 is up" gives
+// Ethernet1/1 and " is up".  Full and abbreviated names are accepted as with
+// ParseIntf but, since the name is followed by other text, no space is
+// allowed between the type and the number.  The longest valid name is used,
+// so "Ethernet1/512" (where 512 is out of range) gives Ethernet1 and "/512".
+// A type with a number needs one: "Ethernets are down" is an error, not
+// Ethernet followed by "s are down".
+func ParsePrefix(s string) (Intf, string, error) {
+	trimmed := strings.TrimLeft(s, " \t")
+	if trimmed == "" {
+		return 0, s, ErrEmptyName
+	}
+
+	t, rest, ok := splitTypeName(trimmed)
+	if !ok {
+		return 0, s, fmt.Errorf("unknown interface type in %q", s)
+	}
+
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return r != '/' && (r < '0' || r > '9')
+	})
+	if end < 0 {
+		end = len(rest)
+	}
+
+	num := rest[:end]
+	var firstErr error
+	for {
+		nums, err := parseNums(num)
+		if err == nil {
+			var i Intf
+			if i, err = newIntf(t, nums...); err == nil {
+				rest = rest[len(num):]
+				if suffix := layoutFor(t).suffix; suffix != "" {
+					rest = strings.TrimPrefix(rest, suffix)
+				}
+				return i, rest, nil
+			}
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+
+		// Drop the last component and try again, down to no number at all
+		// for types without one ("Cpu1" gives Cpu and "1").
+		if num == "" {
+			break
+		}
+		k := strings.LastIndexByte(num, '/')
+		if k < 0 {
+			k = 0
+		}
+		num = num[:k]
+	}
+	return 0, s, fmt.Errorf("invalid interface name in %q: %v", s, firstErr)
+}
+
+// Canonicalize returns the canonical name for any spelling of an interface
+// accepted by ParseIntf, e.g. "Eth1", "Et1", "ethernet1" or "Ethernet 1" all
+// become "Ethernet1".
+func Canonicalize(s string) (string, error) {
+	i, err := ParseIntf(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// ParseID parses a raw interface ID written in hex with a "0x" prefix (e.g.
+// "0x000c0202") or in decimal (e.g. "786946").  The value must fit in 32 bits.
+func ParseID(s string) (Intf, error) {
+	var (
+		n   uint64
+		err error
+	)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		n, err = strconv.ParseUint(s[2:], 16, 32)
+	} else {
+		n, err = strconv.ParseUint(s, 10, 32)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid interface id %q: must be a 32-bit hex or decimal number", s)
+	}
+	return Intf(n), nil
+}
+
+// MustParseIntf is like ParseIntf but panics if the name cannot be parsed.  It
+// is intended for names known at compile time such as in test tables and
+// package level variables.
+func MustParseIntf(s string) Intf {
+	i, err := ParseIntf(s)
+	if err != nil {
+		panic("eosintf: MustParseIntf(" + strconv.Quote(s) + "): " + err.Error())
+	}
+	return i
+}
+
+// typeAliases are other names accepted when parsing, keyed by lowercase name.
+var typeAliases = map[string]IntfType{
+	// Also accepted by the EOS CLI.
+	"eth": TypeEthernet,
+
+	// Rendered by older versions of this package.  Still accepted so that
+	// stored names continue to decode.
+	"mangement": TypeMgmt,
+}
+
+// MatchType returns the types whose full or abbreviated name, or an alias
+// accepted by ParseIntf, starts with prefix, sorted by type value.  Matching
+// is case-insensitive, so "e" and "eth" match Ethernet while "p" matches
+// every type starting with P.  It is intended for tab completion: an empty
+// prefix matches every built-in type.  Registered types cannot be parsed and
+// are never matched.
+func MatchType(prefix string) ([]IntfType, error) {
+	p := strings.ToLower(strings.TrimSpace(prefix))
+
+	seen := make(map[IntfType]bool)
+	match := func(t IntfType, name string) {
+		if strings.HasPrefix(strings.ToLower(name), p) {
+			seen[t] = true
+		}
+	}
+	for t, name := range intfTypeNames {
+		match(t, name)
+	}
+	for t, name := range intfTypeShortNames {
+		match(t, name)
+	}
+	for name, t := range typeAliases {
+		match(t, name)
+	}
+
+	if len(seen) == 0 {
+		return nil, fmt.Errorf("no interface type matches %q", prefix)
+	}
+
+	ts := make([]IntfType, 0, len(seen))
+	for t := range seen {
+		ts = append(ts, t)
+	}
+	sort.Slice(ts, func(i, j int) bool { return ts[i] < ts[j] })
+	return ts, nil
+}
+
+// maxTypeNameLen is the length of the longest full name, abbreviation or
+// alias, bounding the prefixes splitTypeName has to try.
+var maxTypeNameLen = func() int {
+	max := 0
+	for _, name := range intfTypeNames {
+		if len(name) > max {
+			max = len(name)
+		}
+	}
+	for _, name := range intfTypeShortNames {
+		if len(name) > max {
+			max = len(name)
+		}
+	}
+	for name := range typeAliases {
+		if len(name) > max {
+			max = len(name)
+		}
+	}
+	return max
+}()
+
+// splitTypeName finds the longest full or abbreviated type name that prefixes
+// s and returns the type and the remainder of the string.
+func splitTypeName(s string) (IntfType, string, bool) {
+	n := len(s)
+	if n > maxTypeNameLen {
+		n = maxTypeNameLen
+	}
+	for ; n > 0; n-- {
+		if t, ok := TypeFromName(s[:n]); ok {
+			return t, s[n:], true
+		}
+
+		folded := strings.ToLower(s[:n])
+		if t, ok := intfTypesByShortName[folded]; ok {
+			return t, s[n:], true
+		}
+		if t, ok := typeAliases[folded]; ok {
+			return t, s[n:], true
+		}
+	}
+	return 0, s, false
+}
+
+func parseNums(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, "/")
+	nums := make([]int, 0, len(parts))
+	for _, p := range parts {
+		// Atoi also accepts a sign ("+1") which EOS does not.
+		if !isDigits(p) {
+			return nil, fmt.Errorf("bad number %q", p)
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q", p)
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
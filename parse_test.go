@@ -0,0 +1,379 @@
+package eosintf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseIntf(t *testing.T) {
+	tt := []struct {
+		input string
+		want  uint32
+	}{
+		{"Ethernet3/1/2", 0x000c0202},
+		{"Ethernet127/511/511", 0x01ffffff},
+		{"Ethernet1", 0x00000001},
+		{"Ethernet1/2", 0x00000202},
+		{"Ethernet3/0/2", 0x000c0002},
+		{"Vlan100", 0x02000064},
+		{"Management0", 0x04000000},
+		{"Management1", 0x04000001},
+		{"Management1/1", 0x04000201},
+		{"Port-Channel10", 0x0e00000a},
+		{"PeerPort-Channel10", 0x1200000a},
+		{"Cpu", 0x0c000000},
+		{"Null0", 0x08000000},
+		{"Loopback0", 0x06000000},
+		{"Loopback1", 0x06000001},
+		{"fwd1", 0xcc000001},
+		{"T2Recirc1", 0xc6000001},
+		{"DynamicTunnel5.0", 0x74000005},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseIntf(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != Intf(tc.want) {
+				t.Errorf("unexpected interface id (want %#08x, got %#08x)", tc.want, int(got))
+			}
+
+			if s := got.String(); s != tc.input {
+				t.Errorf("unexpected round-trip name (want %q, got %q)", tc.input, s)
+			}
+		})
+	}
+}
+
+func TestParseIntfShort(t *testing.T) {
+	tt := []struct {
+		input string
+		want  int
+	}{
+		{"Et3/1/2", 0x000c0202},
+		{"Et1", 0x00000001},
+		{"Po10", 0x0e00000a},
+		{"Vl100", 0x02000064},
+		{"Ma1", 0x04000001},
+		{"Lo1", 0x06000001},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseIntf(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != Intf(tc.want) {
+				t.Errorf("unexpected interface id (want %#08x, got %#08x)", tc.want, int(got))
+			}
+
+			if s := got.ShortString(); s != tc.input {
+				t.Errorf("unexpected round-trip name (want %q, got %q)", tc.input, s)
+			}
+		})
+	}
+}
+
+func TestParseIntfLoose(t *testing.T) {
+	tt := []struct {
+		input string
+		want  string
+	}{
+		{"  et1 ", "Ethernet1"},
+		{"ETHERNET1", "Ethernet1"},
+		{"Port-channel10", "Port-Channel10"},
+		{"\tvlan100\n", "Vlan100"},
+		{"PO10", "Port-Channel10"},
+		{"mlag1", "mlag1"},
+		{"Mlag1", "Mlag1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseIntf(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.String() != tc.want {
+				t.Errorf("unexpected interface name (want %q, got %q)", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestParseIntfLegacy ensures names stored before the Management spelling was
+// fixed still parse and render with the corrected name.
+func TestParseIntfLegacy(t *testing.T) {
+	got, err := ParseIntf("Mangement1/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != Intf(0x04000201) {
+		t.Errorf("unexpected interface id (want %#08x, got %#08x)", 0x04000201, int(got))
+	}
+
+	if want := "Management1/1"; got.String() != want {
+		t.Errorf("unexpected interface name (want %q, got %q)", want, got.String())
+	}
+}
+
+func TestParseIntfError(t *testing.T) {
+	tt := []string{
+		"",
+		"Bogus1",
+		"Ethernet128/1/1",
+		"Ethernet1/512",
+		"Ethernet1/1/1/1",
+		"Ethernet1/x",
+		"Ethernet+1",
+		"Ethernet+3/+1/+2",
+		"Ethernet-1",
+		"Vlan4096",
+		"Cpu1",
+		"MLAG1",
+		"   ",
+	}
+
+	for _, input := range tt {
+		t.Run(input, func(t *testing.T) {
+			if got, err := ParseIntf(input); err == nil {
+				t.Errorf("expected error, got %#08x", int(got))
+			}
+		})
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	tt := []struct {
+		input string
+		want  string
+		rest  string
+	}{
+		{"Ethernet1/1 is up, line protocol is up", "Ethernet1/1", " is up, line protocol is up"},
+		{"Et3/1/2   connected    1", "Ethernet3/1/2", "   connected    1"},
+		{"Po10 is down", "Port-Channel10", " is down"},
+		{"  Vlan100", "Vlan100", ""},
+		{"Cpu is up", "Cpu", " is up"},
+		{"DynamicTunnel5.0 up", "DynamicTunnel5.0", " up"},
+		{"Ethernet1/512", "Ethernet1", "/512"},
+		{"Ethernet1,Ethernet2", "Ethernet1", ",Ethernet2"},
+		{"Cpu1", "Cpu", "1"},
+		{"Switch1/2 up", "Switch", "1/2 up"},
+		{"DefaultEthManagementPort up", "DefaultEthManagementPort", " up"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, rest, err := ParsePrefix(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.String() != tc.want {
+				t.Errorf("unexpected interface name (want %q, got %q)", tc.want, got)
+			}
+
+			if rest != tc.rest {
+				t.Errorf("unexpected rest (want %q, got %q)", tc.rest, rest)
+			}
+		})
+	}
+}
+
+func TestParsePrefixError(t *testing.T) {
+	for _, input := range []string{
+		"", "  ", "Bogus1 is up", "Vlan4096 is up",
+		"Etx is up", "Ethernets are down", "Vlan is up", "Port-Channel0 is down", "Ethernet0/0",
+	} {
+		if got, rest, err := ParsePrefix(input); err == nil {
+			t.Errorf("%q: expected error, got %#08x and %q", input, int(got), rest)
+		}
+	}
+}
+
+func FuzzParseRoundTrip(f *testing.F) {
+	for _, name := range intfTypeNames {
+		f.Add(name)
+		f.Add(name + "1")
+	}
+	for _, seed := range []string{"Ethernet3/1/2", "Et1", "Management1/1", "DynamicTunnel5.0", "eth 1"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		intf, err := ParseIntf(s)
+		if err != nil {
+			return
+		}
+
+		got, err := ParseIntf(intf.String())
+		if err != nil {
+			t.Fatalf("%q: unexpected error parsing %q: %v", s, intf, err)
+		}
+
+		if got != intf {
+			t.Errorf("%q: ParseIntf(%q) = %#08x, want %#08x", s, intf, int(got), int(intf))
+		}
+	})
+}
+
+func TestMatchType(t *testing.T) {
+	tt := []struct {
+		input string
+		want  []IntfType
+	}{
+		{"e", []IntfType{TypeEthernet}},
+		{"eth", []IntfType{TypeEthernet}},
+		{"ET", []IntfType{TypeEthernet}},
+		{"po", []IntfType{TypePortChan}},
+		{"p", []IntfType{TypePortChan, TypePeerEthernet, TypePeerPortChan, TypePseudowire}},
+		{"peer", []IntfType{TypePeerEthernet, TypePeerPortChan}},
+		{"mlag", []IntfType{TypeMlag, TypeMLAG}},
+		{"Management", []IntfType{TypeMgmt}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := MatchType(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("unexpected types (want %v, got %v)", tc.want, got)
+			}
+			for k := range got {
+				if got[k] != tc.want[k] {
+					t.Errorf("unexpected types (want %v, got %v)", tc.want, got)
+					break
+				}
+			}
+		})
+	}
+
+	if all, err := MatchType(""); err != nil || len(all) != len(intfTypeNames) {
+		t.Errorf("unexpected match for empty prefix (want %d types, got %d, %v)", len(intfTypeNames), len(all), err)
+	}
+
+	for _, input := range []string{"x", "Ethernet1", "bogus"} {
+		if got, err := MatchType(input); err == nil {
+			t.Errorf("%q: expected error, got %v", input, got)
+		}
+	}
+}
+
+// TestParseIntfLong checks that parsing time does not grow with the square of
+// the input length.  Untrusted input reaches ParseIntf through UnmarshalText.
+func TestParseIntfLong(t *testing.T) {
+	long := strings.Repeat("x", 1<<20)
+	if got, err := ParseIntf(long); err == nil {
+		t.Errorf("expected error, got %#08x", int(got))
+	}
+	if got, err := ParseIntf("Ethernet" + long); err == nil {
+		t.Errorf("expected error, got %#08x", int(got))
+	}
+}
+
+func TestMustParseIntf(t *testing.T) {
+	if got := MustParseIntf("Ethernet3/1/2"); got != Intf(0x000c0202) {
+		t.Errorf("unexpected interface id (want %#08x, got %#08x)", 0x000c0202, int(got))
+	}
+}
+
+func TestMustParseIntfPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	MustParseIntf("Bogus1")
+}
+
+func TestParseID(t *testing.T) {
+	tt := []struct {
+		input string
+		want  uint32
+	}{
+		{"0x000c0202", 0x000c0202},
+		{"0X000C0202", 0x000c0202},
+		{"786946", 0x000c0202},
+		{"0xffffffff", 0xffffffff},
+		{"4294967295", 0xffffffff},
+		{"0", 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseID(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != Intf(tc.want) {
+				t.Errorf("unexpected interface id (want %#08x, got %#08x)", tc.want, int(got))
+			}
+		})
+	}
+}
+
+func TestParseIDError(t *testing.T) {
+	for _, input := range []string{"", "0x", "0x100000000", "4294967296", "-1", "Ethernet1", "0xzz"} {
+		if got, err := ParseID(input); err == nil {
+			t.Errorf("%q: expected error, got %#08x", input, int(got))
+		}
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	tt := []struct {
+		input string
+		want  string
+	}{
+		{"Eth1", "Ethernet1"},
+		{"Et1", "Ethernet1"},
+		{"ethernet1", "Ethernet1"},
+		{"Ethernet 1", "Ethernet1"},
+		{"eth 3/1/2", "Ethernet3/1/2"},
+		{"po 10", "Port-Channel10"},
+		{"Ethernet1", "Ethernet1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Canonicalize(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("unexpected canonical name (want %q, got %q)", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeError(t *testing.T) {
+	for _, input := range []string{"", "E1", "Bogus1", "Ethernet1/x"} {
+		if got, err := Canonicalize(input); err == nil {
+			t.Errorf("%q: expected error, got %q", input, got)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, input := range []string{"Ethernet3/1/2", "Vlan100", "UNKNOWN1"} {
+		b.Run(input, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_, _ = ParseIntf(input)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package eosintf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxRangeSize limits how many interfaces a single range may expand to so a
+// typo such as "Ethernet1-127/1-511/1-511" does not exhaust memory.
+const maxRangeSize = 1 << 16
+
+// ParseRange expands an interface range as used in EOS configuration, e.g.
+// "Ethernet1-4" or "Ethernet1/1-1/4", into the individual interfaces.
+//
+// The end of a range may leave off leading components, so "Ethernet1/1-4" is
+// the same as "Ethernet1/1-1/4", and every component ranges independently:
+// "Ethernet3/1-4/2" is Ethernet3/1, Ethernet3/2, Ethernet4/1 and Ethernet4/2.
+// Several ranges may be joined with commas.  Each either starts with a type
+// name or continues the previous type, so "Ethernet1-4,7,Vlan10" is Ethernet1
+// through Ethernet4, Ethernet7 and Vlan10.
+func ParseRange(s string) ([]Intf, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, ErrEmptyName
+	}
+
+	var (
+		xs       []Intf
+		t        IntfType
+		haveType bool
+	)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return nil, fmt.Errorf("invalid interface range %q: empty element", s)
+		}
+
+		rest := item
+		if item[0] < '0' || item[0] > '9' {
+			var ok bool
+			t, rest, ok = splitTypeName(item)
+			if !ok {
+				return nil, fmt.Errorf("unknown interface type in %q", item)
+			}
+			haveType = true
+		} else if !haveType {
+			return nil, fmt.Errorf("invalid interface range %q: %q has no type", s, item)
+		}
+
+		ys, err := expandRange(t, strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid interface range %q: %v", s, err)
+		}
+		xs = append(xs, ys...)
+	}
+	return xs, nil
+}
+
+// expandRange expands a single "from-to" (or just "from") range of type t.
+func expandRange(t IntfType, s string) ([]Intf, error) {
+	lo, hi := s, s
+	if k := strings.IndexByte(s, '-'); k >= 0 {
+		lo, hi = strings.TrimSpace(s[:k]), strings.TrimSpace(s[k+1:])
+		if lo == "" || hi == "" {
+			return nil, fmt.Errorf("malformed range %q", s)
+		}
+	}
+
+	if suffix := layoutFor(t).suffix; suffix != "" {
+		lo = strings.TrimSuffix(lo, suffix)
+		hi = strings.TrimSuffix(hi, suffix)
+	}
+
+	from, err := parseNums(lo)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseNums(hi)
+	if err != nil {
+		return nil, err
+	}
+
+	// The end may leave off leading components (1/1-4).
+	if len(to) > len(from) {
+		return nil, fmt.Errorf("range end %q has more numbers than start %q", hi, lo)
+	}
+	to = append(append([]int(nil), from[:len(from)-len(to)]...), to...)
+
+	size := 1
+	for k := range from {
+		if from[k] > to[k] {
+			return nil, fmt.Errorf("reversed range %q", s)
+		}
+		size *= to[k] - from[k] + 1
+		if size > maxRangeSize {
+			return nil, fmt.Errorf("range %q is larger than %d interfaces", s, maxRangeSize)
+		}
+	}
+
+	xs := make([]Intf, 0, size)
+	cur := append([]int(nil), from...)
+	for {
+		i, err := newIntf(t, cur...)
+		if err != nil {
+			return nil, err
+		}
+		xs = append(xs, i)
+
+		// Step to the next combination with the last component varying
+		// fastest, which keeps the result in natural order.
+		k := len(cur) - 1
+		for ; k >= 0; k-- {
+			if cur[k] < to[k] {
+				cur[k]++
+				break
+			}
+			cur[k] = from[k]
+		}
+		if k < 0 {
+			return xs, nil
+		}
+	}
+}
+
+// FormatRange renders interfaces compactly, collapsing consecutive ports into
+// ranges, e.g. "Ethernet1-4,7,Vlan10".  It is the inverse of ParseRange.  The
+// interfaces are deduplicated and sorted first.  Only the last component is
+// collapsed so Ethernet1/1 to Ethernet1/4 renders as "Ethernet1/1-4", and the
+// type name is only given where the type changes.
+func FormatRange(xs []Intf) string {
+	sorted := NewIntfSet(xs...).Slice()
+
+	var b strings.Builder
+	for k := 0; k < len(sorted); {
+		i := sorted[k]
+		port := i.Port()
+
+		// Extend the run while the next interface follows on.  A bare type
+		// name (port "") cannot start a range.
+		j := k
+		for port != "" && j+1 < len(sorted) && consecutive(sorted[j], sorted[j+1]) {
+			j++
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		if k == 0 || sorted[k-1].Type() != i.Type() {
+			b.WriteString(i.Type().String())
+		}
+		b.WriteString(port)
+
+		if j > k {
+			last := sorted[j]
+			nums := unpackPort(last.Type(), last.RawPort())
+			b.WriteByte('-')
+			b.WriteString(strconv.Itoa(nums[len(nums)-1]))
+			b.WriteString(layoutFor(last.Type()).suffix)
+		}
+		k = j + 1
+	}
+	return b.String()
+}
+
+// consecutive reports whether b immediately follows a: the same built-in
+// type with all but the last component equal and the last one greater by
+// one.
+func consecutive(a, b Intf) bool {
+	t := a.Type()
+	if _, ok := layouts[t]; !ok || b.Type() != t {
+		return false
+	}
+
+	na := unpackPort(t, a.RawPort())
+	nb := unpackPort(t, b.RawPort())
+	if len(na) == 0 {
+		return false
+	}
+	last := len(na) - 1
+	for k := 0; k < last; k++ {
+		if na[k] != nb[k] {
+			return false
+		}
+	}
+	return nb[last] == na[last]+1
+}
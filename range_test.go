@@ -0,0 +1,92 @@
+package eosintf
+
+import "testing"
+
+func TestParseRange(t *testing.T) {
+	tt := []struct {
+		input string
+		want  []string
+	}{
+		{"Ethernet1", []string{"Ethernet1"}},
+		{"Ethernet1-4", []string{"Ethernet1", "Ethernet2", "Ethernet3", "Ethernet4"}},
+		{"Vlan100-102", []string{"Vlan100", "Vlan101", "Vlan102"}},
+		{"Et1/1-1/3", []string{"Ethernet1/1", "Ethernet1/2", "Ethernet1/3"}},
+		{"Ethernet1/1-3", []string{"Ethernet1/1", "Ethernet1/2", "Ethernet1/3"}},
+		{"Ethernet3/1-4/1", []string{"Ethernet3/1", "Ethernet4/1"}},
+		{"Ethernet3/1/1-3/2/2", []string{"Ethernet3/1/1", "Ethernet3/1/2", "Ethernet3/2/1", "Ethernet3/2/2"}},
+		{"Ethernet1-2,7", []string{"Ethernet1", "Ethernet2", "Ethernet7"}},
+		{"Ethernet1, Vlan10-11", []string{"Ethernet1", "Vlan10", "Vlan11"}},
+		{"Port-Channel 10 - 11", []string{"Port-Channel10", "Port-Channel11"}},
+		{"DynamicTunnel1.0-2.0", []string{"DynamicTunnel1.0", "DynamicTunnel2.0"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseRange(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertIntfs(t, "interfaces", got, tc.want...)
+		})
+	}
+}
+
+func TestParseRangeError(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"Ethernet4-1",
+		"Ethernet1/4-1/1",
+		"Ethernet1-",
+		"Ethernet-4",
+		"Ethernet1-4-5",
+		"Ethernet1-1/4",
+		"Ethernet1,,2",
+		"1-4",
+		"Bogus1-4",
+		"Vlan4090-4096",
+		"Ethernet1-127/1-511/1-511",
+	} {
+		if got, err := ParseRange(input); err == nil {
+			t.Errorf("%q: expected error, got %v", input, got)
+		}
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	tt := []struct {
+		input []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"Ethernet1"}, "Ethernet1"},
+		{[]string{"Ethernet3", "Ethernet1", "Ethernet2", "Ethernet4", "Ethernet7"}, "Ethernet1-4,7"},
+		{[]string{"Ethernet1", "Ethernet3", "Ethernet5"}, "Ethernet1,3,5"},
+		{[]string{"Ethernet1/1", "Ethernet1/2", "Ethernet1/3", "Ethernet2/1"}, "Ethernet1/1-3,2/1"},
+		{[]string{"Vlan10", "Ethernet2", "Vlan11", "Ethernet1", "Cpu"}, "Ethernet1-2,Vlan10-11,Cpu"},
+		{[]string{"Ethernet1", "Ethernet1", "Ethernet2"}, "Ethernet1-2"},
+		{[]string{"DynamicTunnel1.0", "DynamicTunnel2.0"}, "DynamicTunnel1.0-2.0"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			xs := parseIntfs(t, tc.input...)
+			got := FormatRange(xs)
+			if got != tc.want {
+				t.Errorf("unexpected range (want %q, got %q)", tc.want, got)
+			}
+
+			if got == "" {
+				return
+			}
+
+			parsed, err := ParseRange(got)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			if back := FormatRange(parsed); back != got {
+				t.Errorf("unexpected round-trip (want %q, got %q)", got, back)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package eosintf
+
+import (
+	"fmt"
+	"sync"
+)
+
+type registeredType struct {
+	name   string
+	decode func(raw int) string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[IntfType]registeredType{}
+)
+
+// RegisterType teaches the package about a type that is not built in.  name is
+// rendered by IntfType.String and decode is called with the raw port bits
+// (see Intf.RawPort) to render the port; a nil decode renders no port.
+// Registered types can be rendered but not parsed.
+//
+// RegisterType is intended to be called from init functions.  It is safe for
+// concurrent use but names already rendered by CachedString are not updated.
+// It panics if the type is built in, already registered or does not fit in
+// the 7 type bits of an ID (above 0x7f).
+func RegisterType(t IntfType, name string, decode func(raw int) string) {
+	if t < 0 || t > maxType {
+		panic(fmt.Sprintf("eosintf: RegisterType called for out of range type %#x", int(t)))
+	}
+	if _, ok := intfTypeNames[t]; ok {
+		panic(fmt.Sprintf("eosintf: RegisterType called for built-in type %s", t))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[t]; ok {
+		panic(fmt.Sprintf("eosintf: RegisterType called twice for type %#x", int(t)))
+	}
+	registry[t] = registeredType{name: name, decode: decode}
+}
+
+func lookupRegistered(t IntfType) (registeredType, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	r, ok := registry[t]
+	return r, ok
+}
@@ -0,0 +1,67 @@
+package eosintf
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRegisterType(t *testing.T) {
+	const typ IntfType = 0x70
+	RegisterType(typ, "Example", func(raw int) string {
+		return strconv.Itoa(raw>>8) + ":" + strconv.Itoa(raw&0xff)
+	})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, typ)
+		registryMu.Unlock()
+	}()
+
+	intf := NewFromRaw(uint32(typ)<<typeShift | 0x0102)
+	if want := "Example1:2"; intf.String() != want {
+		t.Errorf("unexpected interface name (want %q, got %q)", want, intf)
+	}
+
+	if want := "1:2"; intf.Port() != want {
+		t.Errorf("unexpected port (want %q, got %q)", want, intf.Port())
+	}
+
+	if !intf.IsValid() {
+		t.Error("registered type is not valid")
+	}
+}
+
+func TestRegisterTypeNoPort(t *testing.T) {
+	const typ IntfType = 0x71
+	RegisterType(typ, "Singleton", nil)
+	defer func() {
+		registryMu.Lock()
+		delete(registry, typ)
+		registryMu.Unlock()
+	}()
+
+	if got := NewFromRaw(uint32(typ) << typeShift).String(); got != "Singleton" {
+		t.Errorf("unexpected interface name (want %q, got %q)", "Singleton", got)
+	}
+}
+
+func TestRegisterTypeBuiltin(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	RegisterType(TypeEthernet, "Ethernet", nil)
+}
+
+func TestRegisterTypeOutOfRange(t *testing.T) {
+	for _, typ := range []IntfType{-1, maxType + 1, 0x1234} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%#x: expected panic", int(typ))
+				}
+			}()
+			RegisterType(typ, "Bogus", nil)
+		}()
+	}
+}
@@ -0,0 +1,29 @@
+package eosintf
+
+// IntfSlice attaches the methods of sort.Interface to []Intf, sorting in
+// natural order: by type and then numerically by slot, module and port so
+// that Ethernet2 sorts before Ethernet10.
+type IntfSlice []Intf
+
+func (s IntfSlice) Len() int      { return len(s) }
+func (s IntfSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s IntfSlice) Less(i, j int) bool { return s[i].Less(s[j]) }
+
+// Less reports whether i sorts before other in natural order.  Interfaces are
+// ordered by type value first and then numerically by their decoded
+// components (slot, then module, then port).
+func (i Intf) Less(other Intf) bool {
+	if i.Type() != other.Type() {
+		return i.Type() < other.Type()
+	}
+
+	a := unpackPort(i.Type(), i.RawPort())
+	b := unpackPort(other.Type(), other.RawPort())
+	for k := range a {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return false
+}
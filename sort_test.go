@@ -0,0 +1,58 @@
+package eosintf
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestIntfSliceSort(t *testing.T) {
+	input := []string{"Vlan10", "Ethernet10", "Ethernet2/1", "Ethernet1", "Vlan2", "Ethernet2"}
+	want := []string{"Ethernet1", "Ethernet2", "Ethernet10", "Ethernet2/1", "Vlan2", "Vlan10"}
+
+	xs := make([]Intf, len(input))
+	for i, name := range input {
+		intf, err := ParseIntf(name)
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		xs[i] = intf
+	}
+
+	sort.Sort(IntfSlice(xs))
+
+	for i, intf := range xs {
+		if got := intf.String(); got != want[i] {
+			t.Errorf("unexpected interface at %d (want %q, got %q)", i, want[i], got)
+		}
+	}
+}
+
+func TestIntfLess(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{"Ethernet2", "Ethernet10", true},
+		{"Ethernet10", "Ethernet2", false},
+		{"Ethernet10", "Ethernet1/1", true},
+		{"Ethernet3/1/2", "Ethernet3/2/1", true},
+		{"Ethernet1", "Ethernet1", false},
+		{"Ethernet100", "Vlan1", true},
+		{"Vlan1", "Ethernet100", false},
+	}
+
+	for _, tc := range tt {
+		a, err := ParseIntf(tc.a)
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		b, err := ParseIntf(tc.b)
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+
+		if got := a.Less(b); got != tc.want {
+			t.Errorf("%s < %s: want %v, got %v", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
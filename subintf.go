@@ -0,0 +1,60 @@
+package eosintf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SubIntf is a subinterface such as "Ethernet1/1.100".
+//
+// The unit is not part of the 32-bit interface ID.  The 25 port bits of
+// Ethernet are already fully used by slot, module and port, leaving no room
+// for it, so the unit is kept alongside the parent instead of being packed
+// into an Intf.
+//
+// The unit is the 802.1Q encapsulation of the subinterface and so is between
+// 1 and 4094.
+type SubIntf struct {
+	Parent Intf
+	Unit   int
+}
+
+// String renders the parent name followed by "." and the unit, or just the
+// parent name if Unit is 0.
+func (s SubIntf) String() string {
+	if s.Unit == 0 {
+		return s.Parent.String()
+	}
+	return s.Parent.String() + "." + strconv.Itoa(s.Unit)
+}
+
+// ParseSubIntf parses an interface name with an optional subinterface unit
+// such as "Ethernet1.100" or "Ethernet1/1.100".  A name without a unit is
+// returned with a Unit of 0.
+func ParseSubIntf(s string) (SubIntf, error) {
+	// Try the whole string first as some names (DynamicTunnel) already
+	// contain a '.'.
+	parent, err := ParseIntf(s)
+	if err == nil {
+		return SubIntf{Parent: parent}, nil
+	}
+
+	dot := strings.LastIndexByte(s, '.')
+	if dot < 0 {
+		return SubIntf{}, err
+	}
+
+	parent, err = ParseIntf(s[:dot])
+	if err != nil {
+		return SubIntf{}, err
+	}
+
+	// Atoi also accepts a sign ("+5") which EOS does not.
+	unit, err := strconv.Atoi(s[dot+1:])
+	if !isDigits(s[dot+1:]) || err != nil || unit < minVlan || unit > maxVlan {
+		return SubIntf{}, fmt.Errorf("invalid subinterface unit %q in %q", s[dot+1:], s)
+	}
+
+	return SubIntf{Parent: parent, Unit: unit}, nil
+}
@@ -0,0 +1,72 @@
+package eosintf
+
+import "testing"
+
+func TestParseSubIntf(t *testing.T) {
+	tt := []struct {
+		input  string
+		parent int
+		unit   int
+	}{
+		{"Ethernet1.100", 0x00000001, 100},
+		{"Ethernet1/1.100", 0x00000201, 100},
+		{"Ethernet3/1/2.4094", 0x000c0202, 4094},
+		{"Port-Channel10.100", 0x0e00000a, 100},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseSubIntf(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Parent != Intf(tc.parent) || got.Unit != tc.unit {
+				t.Errorf("unexpected subinterface (want %#08x.%d, got %#08x.%d)",
+					tc.parent, tc.unit, int(got.Parent), got.Unit)
+			}
+
+			if s := got.String(); s != tc.input {
+				t.Errorf("unexpected round-trip name (want %q, got %q)", tc.input, s)
+			}
+		})
+	}
+}
+
+func TestParseSubIntfNoUnit(t *testing.T) {
+	got, err := ParseSubIntf("Ethernet1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (SubIntf{Parent: 0x00000001}); got != want {
+		t.Errorf("unexpected subinterface (want %v, got %v)", want, got)
+	}
+
+	if want := "Ethernet1"; got.String() != want {
+		t.Errorf("unexpected name (want %q, got %q)", want, got)
+	}
+
+	back, err := ParseSubIntf(got.String())
+	if err != nil {
+		t.Fatalf("unexpected round-trip error: %v", err)
+	}
+	if back != got {
+		t.Errorf("unexpected round-trip (want %v, got %v)", got, back)
+	}
+}
+
+func TestParseSubIntfError(t *testing.T) {
+	tt := []string{
+		"", "Ethernet1.", "Ethernet1.x", "Ethernet1.0", "Ethernet1.-1", "Bogus1.100",
+		"Port-Channel10.+5", "Ethernet1.4095", "Ethernet1.99999999999999999999",
+	}
+
+	for _, input := range tt {
+		t.Run(input, func(t *testing.T) {
+			if got, err := ParseSubIntf(input); err == nil {
+				t.Errorf("expected error, got %v", got)
+			}
+		})
+	}
+}